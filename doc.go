@@ -6,7 +6,7 @@
 // by the new BSD license.
 
 // Package uuid provides a comprehensive implementation of Universally Unique Identifiers (UUIDs)
-// as defined in RFC 9562. It supports UUID versions 1, 2, 3, 4, 5, 6, and 7 with full compliance
+// as defined in RFC 9562. It supports UUID versions 1, 2, 3, 4, 5, 6, 7, and 8 with full compliance
 // to the specification.
 //
 // Key Features:
@@ -36,6 +36,9 @@
 // Version 7: Unix Epoch time-based UUID. Recommended for database keys and sortable IDs.
 // Implements monotonic counter for UUIDs generated within the same millisecond to ensure sortability.
 //
+// Version 8: Custom UUID with vendor-specific layout. Only version and variant are set,
+// the fields custom_a (48 bits), custom_b (12 bits), and custom_c (62 bits) are free.
+//
 // Basic Usage:
 //
 //	// Create a random UUID (v4)
@@ -91,6 +94,9 @@
 //	}
 //	// Same input always produces the same UUID
 //
+//	// Version 8 with SHA-256 when SHA-1 is not allowed
+//	id, err = uuid.NewV8SHA256(ns, []byte("www.example.com"))
+//
 // Parsing and Validation:
 //
 // Parse UUIDs from various string formats:
@@ -115,6 +121,59 @@
 //	fmt.Println(id.Version()) // e.g., uuid.V7
 //	fmt.Println(id.Variant()) // uuid.VariantRFC4122
 //
+//	// Parse and validate variant and allowed versions
+//	id, err = uuid.ParseStrict(source, uuid.V4, uuid.V7)
+//	if errors.Is(err, uuid.ErrInvalidVersion) {
+//		log.Fatal(err)
+//	}
+//
+// Compact Encodings:
+//
+// For URLs, QR codes, or user-facing identifiers UUIDs can be encoded more
+// compactly. All encodings have a fixed length and are lossless. Base32 uses
+// the alphabet of Douglas Crockford and, like Base58 and Base62, preserves the
+// order of v6 and v7 UUIDs:
+//
+//	s := id.EncodeBase32()    // "01FWHE4YDGFK1SHH6W1G60EECF"
+//	s = id.EncodeBase58()     // "1BihbxwwQ4NZZpKRH9JDCz"
+//	s = id.EncodeBase62()     // "02p5oQZoHTv0zeY5yG21K3"
+//	s = id.EncodeBase64URL()  // "AX8i4nmwfMOYxNwMDAc5jw"
+//
+//	id, err = uuid.ParseBase32(s)
+//
+// Version 7 UUIDs share the millisecond timestamp and the Base32 encoding
+// with ULIDs, so they can be converted:
+//
+//	ulid, err := id.ULID()
+//	id, err = uuid.ParseULID(ulid)      // all 128 bits
+//	id, err = uuid.ParseULIDAsV7(ulid)  // with v7 version and variant
+//
+// Typed IDs:
+//
+// The generic ID type makes UUIDs of different entities distinct types at
+// compile time, while sharing the methods, JSON, and SQL support of UUID:
+//
+//	func FindUser(id uuid.ID[User]) (*User, error)
+//
+//	id, err := uuid.NewID[User]()
+//	id, err = uuid.ParseID[User](source)
+//
+// NullID is the counterpart of NullUUID for nullable columns.
+//
+// A TypedID combines a type prefix with a v7 UUID in lower case Base32, e.g.
+// "user_01h455vb4pex5vsknk084sn02q". The prefix is defined by a type, so
+// parsing always checks it and IDs of different entities are not mixed up:
+//
+//	type UserPrefix struct{}
+//
+//	func (UserPrefix) Prefix() string { return "user" }
+//
+//	userID, err := uuid.NewTypedID[UserPrefix]()
+//	userID, err = uuid.ParseTypedID[UserPrefix](source)
+//	if errors.Is(err, uuid.ErrPrefixMismatch) {
+//		// E.g. an order ID
+//	}
+//
 // DCE Security UUIDs (Version 2):
 //
 // Create UUIDs with embedded POSIX UIDs or GIDs for security contexts:
@@ -139,15 +198,56 @@
 //		log.Fatal(err)
 //	}
 //
+// As the id replaces the lower 32 bits of the timestamp and the clock sequence
+// only has 6 bits, only 64 UUIDs per domain and id can be generated within about
+// 7 minutes. Beyond that ErrV2Exhausted is returned instead of colliding UUIDs.
+// The Time method returns the truncated time.
+//
 // Choosing a UUID Version:
 //
 //   - Use v7 for database primary keys, sortable IDs, or when creation time matters
 //   - Use v4 for general-purpose unique identifiers when randomness is preferred
-//   - Use v5/v3 when you need deterministic UUIDs from names
+//   - Use v5/v3 when you need deterministic UUIDs from names, v8 with SHA-256 if SHA-1 is banned
 //   - Use v2 for security contexts requiring embedded POSIX UID/GID
 //   - Use v6 when you need v1 compatibility with sorting
 //   - Use v1 only for legacy compatibility (consider v6 or v7 instead)
 //
+// Generators:
+//
+// All package-level functions use a default Generator. Own generators with
+// injected clock, entropy source, or node identifier can be created for
+// isolated generation, e.g. per tenant, or for deterministic tests:
+//
+//	gen := uuid.NewGenerator(
+//		uuid.WithClock(clock.Now),
+//		uuid.WithRandom(entropy),
+//		uuid.WithNode([6]byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}),
+//	)
+//	id, err := gen.NewV7()
+//
+// A StateStore like the FileStateStore persists the state of the time-based UUIDs,
+// so that a restarted generator never repeats UUIDs, even after the clock has been
+// set back:
+//
+//	gen := uuid.NewGenerator(
+//		uuid.WithStateStore(uuid.NewFileStateStore("/var/lib/myapp/uuid.state"), time.Second),
+//	)
+//
+// The node identifier of v1, v2, and v6 UUIDs is the MAC address of the host
+// by default. It is looked up with the first time-based UUID, not at package
+// initialization. Instead it can be configured, derived from the hostname or a
+// name like a Kubernetes pod UID, or be random with the multicast bit set. The
+// default generator can be replaced for the whole process:
+//
+//	uuid.SetDefaultGenerator(uuid.NewGenerator(uuid.WithNameNode(podUID)))
+//	node, source, err := uuid.DefaultGenerator().Node()
+//
+// In privacy mode the MAC address is never used. The node is always random with
+// the multicast bit set and can be rotated, e.g. periodically:
+//
+//	uuid.SetDefaultGenerator(uuid.NewGenerator(uuid.WithPrivacy()))
+//	err := uuid.RotateNode()
+//
 // Concurrency:
 //
 // All UUID generation functions are safe for concurrent use:
@@ -170,6 +270,12 @@
 // The implementation uses a monotonic counter to ensure sortability even when generating
 // thousands of UUIDs within a single millisecond.
 //
+// Parse, ParseBytes, AppendString, and AppendShortString do not allocate memory,
+// so UUIDs can be formatted and parsed in hot paths like logging pipelines:
+//
+//	buf = id.AppendString(buf[:0])
+//	id, err := uuid.ParseBytes(buf)
+//
 // Monotonicity Guarantees:
 //
 // UUIDv7 maintains strict monotonicity through a combination of:
@@ -179,6 +285,25 @@
 //
 // This ensures that each UUID is lexicographically greater than the previous, making
 // them ideal for database indexes and time-ordered collections.
+//
+// Generators can use other methods of RFC 9562 Section 6.2 for high-throughput
+// scenarios exceeding the 12-bit counter:
+//
+//	gen := uuid.NewGenerator(uuid.WithV7Method(uuid.V7LongCounter))
+//
+// Available methods are V7Counter (default), V7LongCounter with a 42-bit counter,
+// V7SubMillisecond with 12 additional bits of clock precision, and V7MonotonicRandom
+// incrementing the random bits by a random value.
+//
+// When the fields for the current millisecond are exhausted the generation waits
+// for the next millisecond. To keep latency predictable under bursts a generator
+// can borrow time from the future up to a limit instead:
+//
+//	gen := uuid.NewGenerator(uuid.WithV7DriftLimit(10 * time.Millisecond))
+//	id, err := gen.NewV7()
+//	if errors.Is(err, uuid.ErrClockDriftExceeded) {
+//		// Too many UUIDs in too short time or clock set back too far
+//	}
 package uuid
 
 // EOF
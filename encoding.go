@@ -0,0 +1,188 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+//--------------------
+// CONSTANTS
+//--------------------
+
+// Alphabets of the compact encodings. The Base32, Base58, and Base62
+// alphabets are in ascending ASCII order, so their fixed-width
+// encodings preserve the byte order of the UUIDs.
+const (
+	base32Alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// Lengths of the compact encodings.
+const (
+	base32Length    = 26
+	base58Length    = 22
+	base62Length    = 22
+	base64URLLength = 22
+)
+
+// invalidDigit marks bytes not contained in an alphabet.
+const invalidDigit = 0xff
+
+// Tables mapping characters to their digit values.
+var (
+	base32Digits = newDigits(base32Alphabet)
+	base58Digits = newDigits(base58Alphabet)
+	base62Digits = newDigits(base62Alphabet)
+)
+
+//--------------------
+// ENCODING
+//--------------------
+
+// EncodeBase32 returns the UUID as 26 characters using the Base32
+// alphabet of Douglas Crockford in upper case. The encoding preserves
+// the order of UUIDs, so encoded v6 and v7 UUIDs sort by time.
+func (uuid UUID) EncodeBase32() string {
+	return encodeBase(uuid, base32Alphabet, base32Length)
+}
+
+// EncodeBase58 returns the UUID as 22 characters using the Base58
+// alphabet of Bitcoin, which avoids the easily confused characters
+// 0, O, I, and l. Shorter values are padded with leading '1'.
+func (uuid UUID) EncodeBase58() string {
+	return encodeBase(uuid, base58Alphabet, base58Length)
+}
+
+// EncodeBase62 returns the UUID as 22 alphanumeric characters. Shorter
+// values are padded with leading '0'.
+func (uuid UUID) EncodeBase62() string {
+	return encodeBase(uuid, base62Alphabet, base62Length)
+}
+
+// EncodeBase64URL returns the UUID as 22 characters using the URL-safe
+// Base64 alphabet without padding as defined by RFC 4648. Other than
+// the other compact encodings it does not preserve the order.
+func (uuid UUID) EncodeBase64URL() string {
+	return base64.RawURLEncoding.EncodeToString(uuid[:])
+}
+
+//--------------------
+// PARSING
+//--------------------
+
+// ParseBase32 parses a UUID encoded with EncodeBase32. Lower case
+// letters are accepted as well as the Crockford aliases I and L
+// for 1 and O for 0.
+func ParseBase32(source string) (UUID, error) {
+	return decodeBase(source, "Base32", &base32Digits, 32, base32Length)
+}
+
+// ParseBase58 parses a UUID encoded with EncodeBase58.
+func ParseBase58(source string) (UUID, error) {
+	return decodeBase(source, "Base58", &base58Digits, 58, base58Length)
+}
+
+// ParseBase62 parses a UUID encoded with EncodeBase62.
+func ParseBase62(source string) (UUID, error) {
+	return decodeBase(source, "Base62", &base62Digits, 62, base62Length)
+}
+
+// ParseBase64URL parses a UUID encoded with EncodeBase64URL.
+func ParseBase64URL(source string) (UUID, error) {
+	uuid := UUID{}
+	if len(source) != base64URLLength {
+		return uuid, fmt.Errorf("%w: invalid Base64URL length %d", ErrInvalidFormat, len(source))
+	}
+	if _, err := base64.RawURLEncoding.Strict().Decode(uuid[:], []byte(source)); err != nil {
+		return uuid, fmt.Errorf("%w: invalid Base64URL: %v", ErrInvalidFormat, err)
+	}
+	return uuid, nil
+}
+
+//--------------------
+// PRIVATE HELPERS
+//--------------------
+
+// newDigits creates the table mapping the characters of an alphabet
+// to their digit values. For Base32 the lower case letters and the
+// Crockford aliases are added.
+func newDigits(alphabet string) [256]byte {
+	digits := [256]byte{}
+	for i := range digits {
+		digits[i] = invalidDigit
+	}
+	for i := 0; i < len(alphabet); i++ {
+		digits[alphabet[i]] = byte(i)
+	}
+	if alphabet == base32Alphabet {
+		for i := 0; i < len(alphabet); i++ {
+			if c := alphabet[i]; c >= 'A' && c <= 'Z' {
+				digits[c+'a'-'A'] = byte(i)
+			}
+		}
+		digits['I'], digits['i'] = 1, 1
+		digits['L'], digits['l'] = 1, 1
+		digits['O'], digits['o'] = 0, 0
+	}
+	return digits
+}
+
+// encodeBase encodes the UUID as 128-bit number with the given alphabet
+// into a fixed number of digits.
+func encodeBase(uuid UUID, alphabet string, length int) string {
+	hi := binary.BigEndian.Uint64(uuid[0:8])
+	lo := binary.BigEndian.Uint64(uuid[8:16])
+	base := uint64(len(alphabet))
+	encoded := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		var rem uint64
+		hi, rem = bits.Div64(0, hi, base)
+		lo, rem = bits.Div64(rem, lo, base)
+		encoded[i] = alphabet[rem]
+	}
+	return string(encoded)
+}
+
+// decodeBase decodes a fixed number of digits of the given base into
+// a 128-bit number.
+func decodeBase(source, name string, digits *[256]byte, base uint64, length int) (UUID, error) {
+	uuid := UUID{}
+	if len(source) != length {
+		return uuid, fmt.Errorf("%w: invalid %s length %d", ErrInvalidFormat, name, len(source))
+	}
+	var hi, lo uint64
+	for i := 0; i < len(source); i++ {
+		digit := digits[source[i]]
+		if digit == invalidDigit {
+			return uuid, fmt.Errorf("%w: invalid %s character %q", ErrInvalidFormat, name, source[i])
+		}
+		// (hi, lo) = (hi, lo) * base + digit
+		carry, loMul := bits.Mul64(lo, base)
+		overflow, hiMul := bits.Mul64(hi, base)
+		var c uint64
+		lo, c = bits.Add64(loMul, uint64(digit), 0)
+		hi, c = bits.Add64(hiMul, carry, c)
+		if overflow != 0 || c != 0 {
+			return uuid, fmt.Errorf("%w: %s value exceeds 128 bits", ErrInvalidFormat, name)
+		}
+	}
+	binary.BigEndian.PutUint64(uuid[0:8], hi)
+	binary.BigEndian.PutUint64(uuid[8:16], lo)
+	return uuid, nil
+}
+
+// EOF
@@ -0,0 +1,142 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestEncodingVectors tests the compact encodings with known values.
+func TestEncodingVectors(t *testing.T) {
+	v7, err := uuid.Parse("017F22E2-79B0-7CC3-98C4-DC0C0C07398F")
+	verify.NoError(t, err)
+	tests := []struct {
+		name   string
+		uuid   uuid.UUID
+		encode func(uuid.UUID) string
+		parse  func(string) (uuid.UUID, error)
+		want   string
+	}{
+		{"base32-nil", uuid.Nil, uuid.UUID.EncodeBase32, uuid.ParseBase32, "00000000000000000000000000"},
+		{"base32-max", uuid.Max, uuid.UUID.EncodeBase32, uuid.ParseBase32, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+		{"base32-v7", v7, uuid.UUID.EncodeBase32, uuid.ParseBase32, "01FWHE4YDGFK1SHH6W1G60EECF"},
+		{"base58-nil", uuid.Nil, uuid.UUID.EncodeBase58, uuid.ParseBase58, "1111111111111111111111"},
+		{"base58-max", uuid.Max, uuid.UUID.EncodeBase58, uuid.ParseBase58, "YcVfxkQb6JRzqk5kF2tNLv"},
+		{"base58-v7", v7, uuid.UUID.EncodeBase58, uuid.ParseBase58, "1BihbxwwQ4NZZpKRH9JDCz"},
+		{"base62-nil", uuid.Nil, uuid.UUID.EncodeBase62, uuid.ParseBase62, "0000000000000000000000"},
+		{"base62-max", uuid.Max, uuid.UUID.EncodeBase62, uuid.ParseBase62, "7n42DGM5Tflk9n8mt7Fhc7"},
+		{"base62-v7", v7, uuid.UUID.EncodeBase62, uuid.ParseBase62, "02p5oQZoHTv0zeY5yG21K3"},
+		{"base64url-nil", uuid.Nil, uuid.UUID.EncodeBase64URL, uuid.ParseBase64URL, "AAAAAAAAAAAAAAAAAAAAAA"},
+		{"base64url-max", uuid.Max, uuid.UUID.EncodeBase64URL, uuid.ParseBase64URL, "_____________________w"},
+		{"base64url-v7", v7, uuid.UUID.EncodeBase64URL, uuid.ParseBase64URL, "AX8i4nmwfMOYxNwMDAc5jw"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verify.Equal(t, test.encode(test.uuid), test.want)
+			parsed, err := test.parse(test.want)
+			verify.NoError(t, err)
+			verify.Equal(t, parsed, test.uuid)
+		})
+	}
+}
+
+// TestEncodingRoundTrip tests that the compact encodings are lossless.
+func TestEncodingRoundTrip(t *testing.T) {
+	for range 1000 {
+		u, err := uuid.NewV4()
+		verify.NoError(t, err)
+
+		parsed, err := uuid.ParseBase32(u.EncodeBase32())
+		verify.NoError(t, err)
+		verify.Equal(t, parsed, u)
+		parsed, err = uuid.ParseBase58(u.EncodeBase58())
+		verify.NoError(t, err)
+		verify.Equal(t, parsed, u)
+		parsed, err = uuid.ParseBase62(u.EncodeBase62())
+		verify.NoError(t, err)
+		verify.Equal(t, parsed, u)
+		parsed, err = uuid.ParseBase64URL(u.EncodeBase64URL())
+		verify.NoError(t, err)
+		verify.Equal(t, parsed, u)
+	}
+}
+
+// TestEncodingOrder tests that Base32, Base58, and Base62 preserve the
+// order of v7 UUIDs.
+func TestEncodingOrder(t *testing.T) {
+	start := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	gen := uuid.NewGenerator(uuid.WithClock(steppingClock(start, 10*time.Microsecond)))
+	ids := make([]uuid.UUID, 1000)
+	for i := range ids {
+		u, err := gen.NewV7()
+		verify.NoError(t, err)
+		ids[i] = u
+	}
+	for _, encode := range []func(uuid.UUID) string{
+		uuid.UUID.EncodeBase32,
+		uuid.UUID.EncodeBase58,
+		uuid.UUID.EncodeBase62,
+	} {
+		encoded := make([]string, len(ids))
+		for i, u := range ids {
+			encoded[i] = encode(u)
+		}
+		verify.True(t, slices.IsSorted(encoded), "encoded v7 UUIDs must be sorted")
+	}
+}
+
+// TestParseBase32Crockford tests the relaxed Crockford decoding.
+func TestParseBase32Crockford(t *testing.T) {
+	want, err := uuid.Parse("017F22E2-79B0-7CC3-98C4-DC0C0C07398F")
+	verify.NoError(t, err)
+	parsed, err := uuid.ParseBase32(strings.ToLower("01FWHE4YDGFK1SHH6W1G60EECF"))
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, want)
+	parsed, err = uuid.ParseBase32("O1FWHE4YDGFKISHH6WLG6OEECF")
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, want)
+}
+
+// TestParseBaseInvalid tests the rejection of invalid encodings.
+func TestParseBaseInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		parse  func(string) (uuid.UUID, error)
+		source string
+	}{
+		{"base32-short", uuid.ParseBase32, "7ZZZZZZZZZZZZZZZZZZZZZZZZ"},
+		{"base32-overflow", uuid.ParseBase32, "8ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+		{"base32-char", uuid.ParseBase32, "0000000000000000000000000U"},
+		{"base58-long", uuid.ParseBase58, "11111111111111111111111"},
+		{"base58-overflow", uuid.ParseBase58, "YcVfxkQb6JRzqk5kF2tNLw"},
+		{"base58-char", uuid.ParseBase58, "111111111111111111111O"},
+		{"base62-overflow", uuid.ParseBase62, "7n42DGM5Tflk9n8mt7Fhc8"},
+		{"base62-char", uuid.ParseBase62, "000000000000000000000-"},
+		{"base64url-char", uuid.ParseBase64URL, "AAAAAAAAAAAAAAAAAAAAA+"},
+		{"base64url-bits", uuid.ParseBase64URL, "_____________________x"},
+		{"base64url-long", uuid.ParseBase64URL, "AAAAAAAAAAAAAAAAAAAAAAA"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.parse(test.source)
+			verify.True(t, errors.Is(err, uuid.ErrInvalidFormat))
+		})
+	}
+}
+
+// EOF
@@ -0,0 +1,849 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//--------------------
+// ERRORS
+//--------------------
+
+// ErrClockDriftExceeded is returned when the generation of Version 7
+// UUIDs would need to run further ahead of the real clock than allowed
+// by WithV7DriftLimit.
+var ErrClockDriftExceeded = errors.New("clock drift limit exceeded")
+
+// ErrClockStalled is returned when the generation of Version 7 UUIDs
+// waits for the next millisecond but the clock does not advance, e.g.
+// a fixed clock set with WithClock.
+var ErrClockStalled = errors.New("clock does not advance")
+
+// ErrV2Exhausted is returned when all 64 clock sequences of Version 2
+// UUIDs for a domain and id have been used within the same truncated
+// timestamp of about 7 minutes.
+var ErrV2Exhausted = errors.New("version 2 clock sequences exhausted")
+
+//--------------------
+// OPTIONS
+//--------------------
+
+// Option defines a function setting an option of a Generator.
+type Option func(g *Generator)
+
+// WithClock sets the clock used for time-based UUIDs. It defaults
+// to time.Now. The clock is expected to advance. If the fields of a
+// millisecond are exhausted the generation of Version 7 UUIDs waits
+// for the next one and returns ErrClockStalled if the clock stands
+// still, see also WithV7DriftLimit.
+func WithClock(clock func() time.Time) Option {
+	return func(g *Generator) {
+		g.clock = clock
+	}
+}
+
+// WithRandom sets the source of entropy. It defaults to the
+// cryptographically secure crypto/rand.Reader.
+func WithRandom(random io.Reader) Option {
+	return func(g *Generator) {
+		g.random = random
+	}
+}
+
+// WithNode sets the node identifier used for v1, v2, and v6 UUIDs.
+// It defaults to the MAC address of the host, see NodeSource.
+func WithNode(node [6]byte) Option {
+	return func(g *Generator) {
+		g.node.source = NodeConfigured
+		g.node.node = node
+	}
+}
+
+// WithRandomNode lets the generator use a random node identifier with
+// the multicast bit set instead of the MAC address of the host.
+func WithRandomNode() Option {
+	return func(g *Generator) {
+		g.node.source = NodeRandom
+	}
+}
+
+// WithHostnameNode lets the generator derive the node identifier from
+// the hash of the hostname. It falls back to a random node if the
+// hostname cannot be retrieved.
+func WithHostnameNode() Option {
+	return func(g *Generator) {
+		g.node.source = NodeHostname
+	}
+}
+
+// WithPrivacy ensures that the generator never exposes the MAC address
+// of the host. The node identifier is always random with the multicast
+// bit set, even if another node option is given. It can be replaced with
+// Generator.RotateNode.
+func WithPrivacy() Option {
+	return func(g *Generator) {
+		g.node.private = true
+	}
+}
+
+// WithNameNode lets the generator derive the node identifier from the
+// hash of the given name, e.g. a Kubernetes pod UID or a configured
+// instance name.
+func WithNameNode(name string) Option {
+	return func(g *Generator) {
+		g.node.source = NodeName
+		g.node.name = name
+	}
+}
+
+// V7Method defines how the monotonicity of Version 7 UUIDs generated
+// within the same millisecond is ensured. See RFC 9562 Section 6.2.
+type V7Method int
+
+// Methods for the monotonicity of Version 7 UUIDs.
+const (
+	// V7Counter uses rand_a as 12-bit counter, randomly seeded with each
+	// new millisecond (Method 1). It allows at least 1 UUID and on
+	// average about 2048 UUIDs per millisecond without blocking.
+	V7Counter V7Method = iota
+
+	// V7LongCounter uses a 42-bit counter spanning rand_a and the upper
+	// 30 bits of rand_b, randomly seeded with each new millisecond and
+	// the highest bit cleared (Method 1 with a longer counter). The lower
+	// 32 bits of rand_b stay random.
+	V7LongCounter
+
+	// V7SubMillisecond uses rand_a for 12 additional bits of clock
+	// precision, about 244ns (Method 3). If the clock does not advance
+	// far enough rand_a is incremented.
+	V7SubMillisecond
+
+	// V7MonotonicRandom increments rand_b by a random value between 1
+	// and 2^32 within the same millisecond, carrying over into rand_a
+	// (Method 2).
+	V7MonotonicRandom
+)
+
+// WithLegacyV1 lets the generator create Version 1 UUIDs in the former
+// layout of this package with little-endian fields, e.g. for systems
+// still expecting it. It is not RFC compliant, use UUID.LegacyV1Time
+// to decode the time of these UUIDs. UUID.ClockSequence and UUID.ToV6
+// only apply to the RFC layout and return wrong values for them.
+func WithLegacyV1() Option {
+	return func(g *Generator) {
+		g.legacyV1 = true
+	}
+}
+
+// WithStateStore sets a store for the state of the time-based UUIDs.
+// The state is loaded before the first v6 or v7 UUID is generated and
+// checkpointed with a timestamp reserved for the given interval ahead.
+// So a restarted generator continues behind all UUIDs created before,
+// even if the clock has been set back in the meantime. Longer intervals
+// mean less writes, but a larger jump into the future after a restart.
+// The interval defaults to one second.
+func WithStateStore(store StateStore, interval time.Duration) Option {
+	return func(g *Generator) {
+		if interval <= 0 {
+			interval = time.Second
+		}
+		g.persistence = &persistence{
+			store:    store,
+			interval: interval,
+		}
+	}
+}
+
+// WithV7DriftLimit lets the generation of Version 7 UUIDs borrow time
+// from the future instead of waiting for the next millisecond when
+// the fields for the current millisecond are exhausted. The logical
+// clock may run ahead of the real clock up to the given limit, then
+// ErrClockDriftExceeded is returned. The limit is also checked while
+// a clock going backwards is compensated. The bound loaded from the
+// store of WithStateStore after a restart does not count as drift. A
+// limit of zero, the default, disables the borrowing.
+func WithV7DriftLimit(limit time.Duration) Option {
+	return func(g *Generator) {
+		g.v7DriftLimit = limit
+	}
+}
+
+// WithV7Method sets the method ensuring the monotonicity of Version 7
+// UUIDs. It defaults to V7Counter.
+func WithV7Method(method V7Method) Option {
+	return func(g *Generator) {
+		g.v7Method = method
+	}
+}
+
+//--------------------
+// GENERATOR
+//--------------------
+
+// Generator creates UUIDs of all versions. Each generator keeps its
+// own state for the monotonic time-based UUIDs, so multiple generators
+// can be used independently, e.g. per tenant or for deterministic tests.
+// The package-level functions like NewV7 use a default generator.
+//
+// A Generator is safe for concurrent use.
+type Generator struct {
+	clock        func() time.Time
+	random       io.Reader
+	node         nodeState
+	legacyV1     bool
+	v7Method     V7Method
+	v7DriftLimit time.Duration
+	persistence  *persistence
+	v2           v2State
+	v6           v6State
+	v7           v7State
+}
+
+// defaultGenerator is used by the package-level functions.
+var defaultGenerator atomic.Pointer[Generator]
+
+func init() {
+	defaultGenerator.Store(NewGenerator())
+}
+
+// DefaultGenerator returns the generator used by the package-level
+// functions.
+func DefaultGenerator() *Generator {
+	return defaultGenerator.Load()
+}
+
+// SetDefaultGenerator replaces the generator used by the package-level
+// functions, e.g. to configure the node identifier for the whole
+// process. It should be called during initialization, as the state of
+// the former generator for monotonic UUIDs is not taken over.
+func SetDefaultGenerator(g *Generator) {
+	defaultGenerator.Store(g)
+}
+
+// NewGenerator creates a new UUID generator with the given options.
+func NewGenerator(options ...Option) *Generator {
+	g := &Generator{
+		clock:  time.Now,
+		random: rand.Reader,
+	}
+	for _, option := range options {
+		option(g)
+	}
+	return g
+}
+
+// NewV1 generates a new UUID based on version 1 (MAC address and
+// date-time).
+func (g *Generator) NewV1() (UUID, error) {
+	node, err := g.currentNode()
+	if err != nil {
+		return UUID{}, err
+	}
+	// Get monotonic clock sequence and adjusted timestamp shared with v6
+	now, clockSeq, err := g.getV6ClockSeq(g.gregorianNow())
+	if err != nil {
+		return UUID{}, err
+	}
+	return g.newV1(now, clockSeq, node), nil
+}
+
+// NewV1At generates a new UUID based on version 1 for the given time.
+// See the package-level function NewV1At for details.
+func (g *Generator) NewV1At(t time.Time) (UUID, error) {
+	timestamp, err := checkedGregorianTimestamp(t)
+	if err != nil {
+		return UUID{}, err
+	}
+	node, err := g.currentNode()
+	if err != nil {
+		return UUID{}, err
+	}
+	// Random clock sequence, the monotonic state is not touched
+	clockSeqRand := [2]byte{}
+	if err := g.read(clockSeqRand[:]); err != nil {
+		return UUID{}, err
+	}
+	clockSeq := binary.BigEndian.Uint16(clockSeqRand[:]) & 0x3fff
+	return g.newV1(timestamp, clockSeq, node), nil
+}
+
+// newV1 creates a UUID based on version 1 for the given Gregorian
+// timestamp, clock sequence, and node.
+func (g *Generator) newV1(now uint64, clockSeq uint16, node [6]byte) UUID {
+	uuid := UUID{}
+
+	if g.legacyV1 {
+		// Former little-endian layout for compatibility
+		binary.LittleEndian.PutUint32(uuid[0:4], uint32(now))
+		binary.LittleEndian.PutUint16(uuid[4:6], uint16(now>>32))
+		binary.LittleEndian.PutUint16(uuid[6:8], uint16(now>>48)&0x0fff)
+		binary.LittleEndian.PutUint16(uuid[8:10], clockSeq)
+	} else {
+		// Network byte order as defined by RFC 9562
+		uuid.setV1Time(now)
+		binary.BigEndian.PutUint16(uuid[8:10], clockSeq)
+	}
+	copy(uuid[10:16], node[:])
+
+	uuid.setVersion(V1)
+	uuid.setVariant()
+	return uuid
+}
+
+// NewV2 generates a new UUID based on version 2 (DCE Security).
+// See the package-level function NewV2 for details.
+func (g *Generator) NewV2(domain Domain, id uint32) (UUID, error) {
+	uuid := UUID{}
+	node, err := g.currentNode()
+	if err != nil {
+		return uuid, err
+	}
+
+	// Get truncated timestamp and clock sequence of the domain and id
+	tick, clockSeq, err := g.getV2ClockSeq(domain, id, g.gregorianNow())
+	if err != nil {
+		return uuid, err
+	}
+
+	// The local ID replaces time_low, the domain clock_seq_low
+	binary.BigEndian.PutUint32(uuid[0:4], id)
+	binary.BigEndian.PutUint16(uuid[4:6], uint16(tick))
+	binary.BigEndian.PutUint16(uuid[6:8], uint16(tick>>16)&0x0fff)
+	uuid[8] = clockSeq
+	uuid[9] = byte(domain)
+	copy(uuid[10:16], node[:])
+
+	uuid.setVersion(V2)
+	uuid.setVariant()
+	return uuid, nil
+}
+
+// NewV2Person returns a DCE Security (Version 2) UUID in the Person
+// domain with the id returned by os.Getuid.
+func (g *Generator) NewV2Person() (UUID, error) {
+	return g.NewV2(Person, uint32(os.Getuid()))
+}
+
+// NewV2Group returns a DCE Security (Version 2) UUID in the Group
+// domain with the id returned by os.Getgid.
+func (g *Generator) NewV2Group() (UUID, error) {
+	return g.NewV2(Group, uint32(os.Getgid()))
+}
+
+// NewV3 generates a new UUID based on version 3 (MD5 hash of a namespace
+// and a name). It is deterministic and needs no generator state.
+func (g *Generator) NewV3(ns UUID, name []byte) (UUID, error) {
+	return NewV3(ns, name)
+}
+
+// NewV4 generates a new UUID based on version 4 (random number).
+func (g *Generator) NewV4() (UUID, error) {
+	uuid := UUID{}
+	if err := g.read(uuid[:]); err != nil {
+		return uuid, err
+	}
+
+	uuid.setVersion(V4)
+	uuid.setVariant()
+	return uuid, nil
+}
+
+// NewV5 generates a new UUID based on version 5 (SHA1 hash of a namespace
+// and a name). It is deterministic and needs no generator state.
+func (g *Generator) NewV5(ns UUID, name []byte) (UUID, error) {
+	return NewV5(ns, name)
+}
+
+// NewV6 generates a new UUID based on version 6 (reordered Gregorian timestamp).
+// See the package-level function NewV6 for details.
+func (g *Generator) NewV6() (UUID, error) {
+	uuid := UUID{}
+	node, err := g.currentNode()
+	if err != nil {
+		return uuid, err
+	}
+	now := g.gregorianNow()
+
+	// Get monotonic clock sequence and adjusted timestamp
+	adjustedNow, clockSeq, err := g.getV6ClockSeq(now)
+	if err != nil {
+		return uuid, err
+	}
+
+	// Store timestamp in big-endian order for v6
+	uuid.setV6Time(adjustedNow)
+	binary.BigEndian.PutUint16(uuid[8:10], clockSeq)
+	copy(uuid[10:16], node[:])
+
+	uuid.setVersion(V6)
+	uuid.setVariant()
+	return uuid, nil
+}
+
+// NewV6At generates a new UUID based on version 6 for the given time.
+// See the package-level function NewV6At for details.
+func (g *Generator) NewV6At(t time.Time) (UUID, error) {
+	uuid := UUID{}
+	timestamp, err := checkedGregorianTimestamp(t)
+	if err != nil {
+		return uuid, err
+	}
+	node, err := g.currentNode()
+	if err != nil {
+		return uuid, err
+	}
+
+	// Random clock sequence, the monotonic state is not touched
+	if err := g.read(uuid[8:10]); err != nil {
+		return uuid, err
+	}
+	uuid.setV6Time(timestamp)
+	copy(uuid[10:16], node[:])
+
+	uuid.setVersion(V6)
+	uuid.setVariant()
+	return uuid, nil
+}
+
+// NewV7 generates a new UUID based on version 7 (Unix Epoch timestamp).
+// See the package-level function NewV7 for details.
+func (g *Generator) NewV7() (UUID, error) {
+	uuid := UUID{}
+
+	// Get time and random fields with monotonicity guarantee
+	ms, randA, randB, err := g.getV7Time()
+	if err != nil {
+		return uuid, err
+	}
+
+	// Fill first 48 bits with timestamp (milliseconds)
+	uuid.setV7Time(ms)
+
+	// Fill next 12 bits (after version) and remaining 62 bits (after
+	// variant) depending on the method ensuring monotonicity
+	binary.BigEndian.PutUint16(uuid[6:8], randA)
+	binary.BigEndian.PutUint64(uuid[8:16], randB)
+
+	uuid.setVersion(V7)
+	uuid.setVariant()
+	return uuid, nil
+}
+
+// NewV7At generates a new UUID based on version 7 for the given time.
+// See the package-level function NewV7At for details.
+func (g *Generator) NewV7At(t time.Time) (UUID, error) {
+	uuid := UUID{}
+	ms, err := checkedUnixMilli(t)
+	if err != nil {
+		return uuid, err
+	}
+
+	// Random rand_a and rand_b, the monotonic state is not touched
+	if err := g.read(uuid[6:16]); err != nil {
+		return uuid, err
+	}
+	uuid.setV7Time(ms)
+
+	uuid.setVersion(V7)
+	uuid.setVariant()
+	return uuid, nil
+}
+
+//--------------------
+// PRIVATE HELPERS
+//--------------------
+
+// read fills the buffer with data of the generator's entropy source.
+func (g *Generator) read(buf []byte) error {
+	_, err := io.ReadFull(g.random, buf)
+	return err
+}
+
+// gregorianNow returns the current time of the generator's clock in
+// 100ns intervals since the Gregorian epoch.
+func (g *Generator) gregorianNow() uint64 {
+	return gregorianTimestamp(g.clock())
+}
+
+// v2Key identifies the clock sequence state of Version 2 UUIDs.
+type v2Key struct {
+	domain Domain
+	id     uint32
+}
+
+// v2Entry holds the clock sequences of a domain and id used within
+// a truncated timestamp.
+type v2Entry struct {
+	tick     uint32 // Truncated timestamp (upper 28 bits)
+	clockSeq uint8  // Last 6-bit clock sequence
+	used     int    // Number of used clock sequences
+}
+
+// v2State holds the state for UUID v2 generation.
+type v2State struct {
+	mu       sync.Mutex
+	lastTick uint32 // Last truncated timestamp of all entries
+	entries  map[v2Key]*v2Entry
+}
+
+// v2ClockSeqs is the number of 6-bit clock sequences of UUID v2.
+const v2ClockSeqs = 64
+
+// v2MaxEntries limits the number of domain and id pairs tracked within
+// a truncated timestamp. The entries are only released when it changes.
+const v2MaxEntries = 1 << 16
+
+// v6State holds the state for monotonic UUID v1 and v6 generation.
+type v6State struct {
+	mu           sync.Mutex
+	lastTime     uint64 // Last timestamp (in 100ns intervals)
+	lastClockSeq uint16 // Last clock sequence
+	reserved     uint64 // Timestamp bound stored in the state store
+}
+
+// v7State holds the state for monotonic UUID v7 generation.
+type v7State struct {
+	mu     sync.Mutex
+	lastMs int64  // Last millisecond timestamp
+	randA  uint16 // Last 12-bit rand_a field
+	randB  uint64 // Last 62-bit rand_b field
+
+	reservedMs int64 // Millisecond bound stored in the state store
+	restoredMs int64 // Millisecond bound loaded from the state store
+}
+
+// Masks and limits of the v7 fields.
+const (
+	v7RandAMax     = 0x0fff
+	v7RandBMask    = 0x3fffffffffffffff
+	v7LongCountMax = 0x3ffffffffff // 42 bits
+)
+
+// v7MaxWait limits how long the generation of Version 7 UUIDs waits
+// for the next millisecond while the clock does not advance at all.
+const v7MaxWait = 100 * time.Millisecond
+
+// getV7Time returns the current time in milliseconds and the fields rand_a
+// and rand_b depending on the configured V7Method. The returned values
+// ensure that each UUID v7 is greater than the previous one, even when
+// multiple UUIDs are generated within the same millisecond.
+func (g *Generator) getV7Time() (ms int64, randA uint16, randB uint64, err error) {
+	if err := g.loadState(); err != nil {
+		return 0, 0, 0, err
+	}
+
+	g.v7.mu.Lock()
+	defer g.v7.mu.Unlock()
+
+	// Get current time - capture once to avoid inconsistencies
+	now := g.clock()
+
+	if now.UnixMilli() > g.v7.lastMs {
+		// New millisecond: initialize the fields
+		if err := g.initV7(now); err != nil {
+			return 0, 0, 0, err
+		}
+	} else if err := g.incrementV7OrNext(now); err != nil {
+		return 0, 0, 0, err
+	}
+
+	if err := g.checkpointV7(); err != nil {
+		return 0, 0, 0, err
+	}
+	return g.v7.lastMs, g.v7.randA, g.v7.randB, nil
+}
+
+// incrementV7OrNext increments the v7 state within the same millisecond
+// or, if the clock went backwards, the last known one. In case of an
+// overflow it continues with the next millisecond.
+func (g *Generator) incrementV7OrNext(now time.Time) error {
+	// The last known millisecond may be ahead of the clock after
+	// overflows or a clock going backwards
+	if drift := g.v7Drift(g.v7.lastMs, now); g.v7DriftLimit > 0 && drift > g.v7DriftLimit {
+		return fmt.Errorf("%w: drift of %v exceeds limit of %v", ErrClockDriftExceeded, drift, g.v7DriftLimit)
+	}
+	overflow, err := g.incrementV7(now)
+	if err != nil {
+		return err
+	}
+	if overflow {
+		next := time.UnixMilli(g.v7.lastMs + 1)
+		switch {
+		case g.v7DriftLimit > 0:
+			// Borrow time from the future as long as the drift to the
+			// real clock stays within the limit
+			if drift := g.v7Drift(next.UnixMilli(), now); drift > g.v7DriftLimit {
+				return fmt.Errorf("%w: drift of %v exceeds limit of %v", ErrClockDriftExceeded, drift, g.v7DriftLimit)
+			}
+		case now.UnixMilli() == g.v7.lastMs:
+			// Overflow - wait for the next millisecond
+			if next, err = g.waitV7(); err != nil {
+				return err
+			}
+		}
+		// Otherwise the clock went backwards, continue with the next
+		// millisecond
+		if err := g.initV7(next); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// v7Drift returns how far the given millisecond is ahead of the clock.
+// The bound loaded from the state store does not count as drift, after
+// a restart the generation has to continue behind it anyway.
+func (g *Generator) v7Drift(ms int64, now time.Time) time.Duration {
+	if restored := time.UnixMilli(g.v7.restoredMs); restored.After(now) {
+		now = restored
+	}
+	return time.UnixMilli(ms).Sub(now)
+}
+
+// waitV7 waits for the clock to pass the millisecond of the v7 state.
+// A clock not advancing at all within v7MaxWait results in ErrClockStalled
+// instead of blocking forever.
+func (g *Generator) waitV7() (time.Time, error) {
+	last := g.clock()
+	deadline := time.Now().Add(v7MaxWait)
+	for {
+		time.Sleep(time.Microsecond * 100)
+		now := g.clock()
+		switch {
+		case now.UnixMilli() > g.v7.lastMs:
+			return now, nil
+		case now.After(last):
+			last = now
+			deadline = time.Now().Add(v7MaxWait)
+		case time.Now().After(deadline):
+			return time.Time{}, fmt.Errorf("%w: clock stands still for %v", ErrClockStalled, v7MaxWait)
+		}
+	}
+}
+
+// initV7 initializes the v7 state for a new millisecond.
+func (g *Generator) initV7(now time.Time) error {
+	randA, randB, err := g.randomV7()
+	if err != nil {
+		return err
+	}
+	switch g.v7Method {
+	case V7LongCounter:
+		// Random seed of the counter with the highest bit cleared to
+		// leave room for increments, lower 32 bits of rand_b random
+		counter := (uint64(randA)<<30 | randB>>32) >> 1
+		g.v7.randA = uint16(counter >> 30)
+		g.v7.randB = (counter&0x3fffffff)<<32 | randB&0xffffffff
+	case V7SubMillisecond:
+		g.v7.randA = subMillisecond(now)
+		g.v7.randB = randB
+	case V7MonotonicRandom:
+		// Highest bit of rand_b cleared to leave room for increments
+		g.v7.randA = randA
+		g.v7.randB = randB >> 1
+	default:
+		g.v7.randA = randA
+		g.v7.randB = randB
+	}
+	g.v7.lastMs = now.UnixMilli()
+	return nil
+}
+
+// incrementV7 increments the v7 state within the same millisecond. It
+// returns true if the fields overflow.
+func (g *Generator) incrementV7(now time.Time) (bool, error) {
+	_, randB, err := g.randomV7()
+	if err != nil {
+		return false, err
+	}
+	switch g.v7Method {
+	case V7LongCounter:
+		counter := uint64(g.v7.randA)<<30 | g.v7.randB>>32
+		if counter == v7LongCountMax {
+			return true, nil
+		}
+		counter++
+		g.v7.randA = uint16(counter >> 30)
+		g.v7.randB = (counter&0x3fffffff)<<32 | randB&0xffffffff
+	case V7SubMillisecond:
+		randA := g.v7.randA + 1
+		if now.UnixMilli() == g.v7.lastMs {
+			randA = max(randA, subMillisecond(now))
+		}
+		if randA > v7RandAMax {
+			return true, nil
+		}
+		g.v7.randA = randA
+		g.v7.randB = randB
+	case V7MonotonicRandom:
+		// Increment rand_b by a random value between 1 and 2^32, carry
+		// into rand_a
+		randA := g.v7.randA
+		randB = g.v7.randB + randB>>30 + 1
+		if randB > v7RandBMask {
+			if randA == v7RandAMax {
+				return true, nil
+			}
+			randA++
+			randB &= v7RandBMask
+		}
+		g.v7.randA = randA
+		g.v7.randB = randB
+	default:
+		if g.v7.randA == v7RandAMax {
+			return true, nil
+		}
+		g.v7.randA++
+		g.v7.randB = randB
+	}
+	return false, nil
+}
+
+// randomV7 returns random values for rand_a and rand_b.
+func (g *Generator) randomV7() (randA uint16, randB uint64, err error) {
+	randBytes := [10]byte{}
+	if err := g.read(randBytes[:]); err != nil {
+		return 0, 0, err
+	}
+	randA = binary.BigEndian.Uint16(randBytes[0:2]) & v7RandAMax
+	randB = binary.BigEndian.Uint64(randBytes[2:10]) & v7RandBMask
+	return randA, randB, nil
+}
+
+// subMillisecond returns the fraction of the millisecond of the given
+// time scaled to 12 bits as described by RFC 9562 Section 6.2 Method 3.
+func subMillisecond(t time.Time) uint16 {
+	return uint16(int64(t.Nanosecond()%1e6) * 4096 / 1e6)
+}
+
+// getV2ClockSeq returns the truncated timestamp and the next clock
+// sequence for a domain and id. The lower 32 bits of the timestamp
+// are replaced by the id, so the remaining 28 bits change only about
+// every 7 minutes. Within this time each of the 64 clock sequences
+// is used once per domain and id, afterwards ErrV2Exhausted is returned.
+// The same error is returned for new pairs if already v2MaxEntries pairs
+// are tracked, so that the memory stays bounded until the next change.
+func (g *Generator) getV2ClockSeq(domain Domain, id uint32, timestamp uint64) (tick uint32, clockSeq uint8, err error) {
+	tick = uint32(timestamp >> 32)
+
+	g.v2.mu.Lock()
+	defer g.v2.mu.Unlock()
+
+	switch {
+	case tick > g.v2.lastTick:
+		// New truncated timestamp: entries of former ones are not
+		// needed anymore, as they never will be used again
+		g.v2.lastTick = tick
+		for key, entry := range g.v2.entries {
+			if entry.tick < tick {
+				delete(g.v2.entries, key)
+			}
+		}
+	case tick < g.v2.lastTick:
+		// Clock went backwards: stay with the last truncated timestamp
+		tick = g.v2.lastTick
+	}
+	if g.v2.entries == nil {
+		g.v2.entries = make(map[v2Key]*v2Entry)
+	}
+
+	key := v2Key{domain, id}
+	entry, ok := g.v2.entries[key]
+	if !ok {
+		if len(g.v2.entries) >= v2MaxEntries {
+			return 0, 0, fmt.Errorf("%w: more than %d domain and id pairs", ErrV2Exhausted, v2MaxEntries)
+		}
+		// First UUID for domain and id: initialize with random clock sequence
+		randByte := [1]byte{}
+		if err := g.read(randByte[:]); err != nil {
+			return 0, 0, err
+		}
+		g.v2.entries[key] = &v2Entry{
+			tick:     tick,
+			clockSeq: randByte[0] & 0x3f,
+			used:     1,
+		}
+		return tick, randByte[0] & 0x3f, nil
+	}
+	if entry.used == v2ClockSeqs {
+		return 0, 0, fmt.Errorf("%w: domain %s and id %d", ErrV2Exhausted, domain, id)
+	}
+	entry.clockSeq = (entry.clockSeq + 1) & 0x3f
+	entry.used++
+	return tick, entry.clockSeq, nil
+}
+
+// getV6ClockSeq returns a monotonic clock sequence for UUID v1 and v6 generation.
+// The returned clock sequence ensures that each UUID v6 is greater than the previous one,
+// even when multiple UUIDs are generated within the same timestamp period.
+func (g *Generator) getV6ClockSeq(timestamp uint64) (adjustedTimestamp uint64, clockSeq uint16, err error) {
+	if err := g.loadState(); err != nil {
+		return 0, 0, err
+	}
+
+	g.v6.mu.Lock()
+	defer g.v6.mu.Unlock()
+
+	switch {
+	case timestamp == g.v6.lastTime:
+		// Same timestamp: increment clock sequence
+		if g.v6.lastClockSeq == 0x3FFF {
+			// Clock sequence overflow - instead of waiting for the clock
+			// continue with the next timestamp unit (100ns) and a new
+			// random clock sequence, the time never goes backwards
+			randBytes := make([]byte, 2)
+			if err := g.read(randBytes); err != nil {
+				return 0, 0, err
+			}
+			g.v6.lastClockSeq = binary.BigEndian.Uint16(randBytes) & 0x3FFF
+			g.v6.lastTime++
+			timestamp = g.v6.lastTime
+		} else {
+			g.v6.lastClockSeq++
+		}
+	case timestamp > g.v6.lastTime:
+		// New timestamp: initialize with random clock sequence
+		randBytes := make([]byte, 2)
+		if err := g.read(randBytes); err != nil {
+			return 0, 0, err
+		}
+		g.v6.lastClockSeq = binary.BigEndian.Uint16(randBytes) & 0x3FFF
+		g.v6.lastTime = timestamp
+	default:
+		// Clock went backwards - this is problematic
+		// Use the last known time and increment clock sequence
+		if g.v6.lastClockSeq == 0x3FFF {
+			g.v6.lastClockSeq = 0
+			g.v6.lastTime++
+		} else {
+			g.v6.lastClockSeq++
+		}
+		timestamp = g.v6.lastTime
+	}
+
+	if err := g.checkpointV6(); err != nil {
+		return 0, 0, err
+	}
+	return timestamp, g.v6.lastClockSeq, nil
+}
+
+// EOF
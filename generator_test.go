@@ -0,0 +1,657 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestGeneratorDeterministic tests that generators with the same clock
+// and entropy source create the same UUIDs.
+func TestGeneratorDeterministic(t *testing.T) {
+	start := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	newGenerator := func() *uuid.Generator {
+		return uuid.NewGenerator(
+			uuid.WithClock(steppingClock(start, time.Microsecond)),
+			uuid.WithRandom(&sequenceReader{}),
+			uuid.WithNode([6]byte{1, 2, 3, 4, 5, 6}),
+		)
+	}
+	genA := newGenerator()
+	genB := newGenerator()
+
+	for range 100 {
+		uuidA, err := genA.NewV4()
+		verify.NoError(t, err)
+		uuidB, err := genB.NewV4()
+		verify.NoError(t, err)
+		verify.Equal(t, uuidA, uuidB)
+
+		uuidA, err = genA.NewV6()
+		verify.NoError(t, err)
+		uuidB, err = genB.NewV6()
+		verify.NoError(t, err)
+		verify.Equal(t, uuidA, uuidB)
+
+		uuidA, err = genA.NewV7()
+		verify.NoError(t, err)
+		uuidB, err = genB.NewV7()
+		verify.NoError(t, err)
+		verify.Equal(t, uuidA, uuidB)
+	}
+}
+
+// TestGeneratorOptions tests that the options are used for generation.
+func TestGeneratorOptions(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	node := [6]byte{0xde, 0xad, 0xbe, 0xef, 0x00, 0x01}
+	gen := uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithRandom(&sequenceReader{}),
+		uuid.WithNode(node),
+	)
+
+	uuidV6, err := gen.NewV6()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV6.Version(), uuid.V6)
+	verify.Equal(t, uuidV6.NodeID(), node)
+	ts, err := uuidV6.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now), "v6 time must be the generator clock")
+
+	uuidV7, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV7.Version(), uuid.V7)
+	ts, err = uuidV7.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now), "v7 time must be the generator clock")
+
+	uuidV2, err := gen.NewV2(uuid.Group, 42)
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV2.Version(), uuid.V2)
+	verify.Equal(t, uuidV2.NodeID(), node)
+	verify.Equal(t, uuidV2.ID(), uint32(42))
+
+	// Failing entropy source
+	gen = uuid.NewGenerator(uuid.WithRandom(failingReader{}))
+	_, err = gen.NewV4()
+	verify.ErrorContains(t, err, "entropy exhausted")
+	_, err = gen.NewV7()
+	verify.ErrorContains(t, err, "entropy exhausted")
+}
+
+// TestGeneratorIsolation tests that generators keep separate states.
+func TestGeneratorIsolation(t *testing.T) {
+	now := time.Now()
+	clock := func() time.Time { return now }
+	genA := uuid.NewGenerator(uuid.WithClock(clock), uuid.WithRandom(&sequenceReader{}))
+	genB := uuid.NewGenerator(uuid.WithClock(clock), uuid.WithRandom(&sequenceReader{}))
+
+	// Counting up in genA does not influence genB.
+	uuidA1, err := genA.NewV7()
+	verify.NoError(t, err)
+	uuidA2, err := genA.NewV7()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidA2.Counter(), uuidA1.Counter()+1)
+
+	uuidB1, err := genB.NewV7()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidB1, uuidA1)
+}
+
+// TestGeneratorV1 tests the layout and monotonicity of v1 UUIDs.
+func TestGeneratorV1(t *testing.T) {
+	// Time and node of RFC 9562 Appendix A.1
+	now := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	node := [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}
+	gen := uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithRandom(&sequenceReader{}),
+		uuid.WithNode(node),
+	)
+
+	uuidV1, err := gen.NewV1()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV1.String()[:18], "c232ab00-9414-11ec")
+	verify.Equal(t, uuidV1.Variant(), uuid.VariantRFC4122)
+	verify.Equal(t, uuidV1.NodeID(), node)
+	ts, err := uuidV1.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now), "v1 time must be generator time")
+
+	// Clock sequence is shared with v6
+	uuidV6, err := gen.NewV6()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV6.ClockSequence(), uuidV1.ClockSequence()+1)
+	uuidV1, err = gen.NewV1()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV1.ClockSequence(), uuidV6.ClockSequence()+1)
+
+	// Legacy layout
+	gen = uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithRandom(&sequenceReader{}),
+		uuid.WithNode(node),
+		uuid.WithLegacyV1(),
+	)
+	uuidLegacy, err := gen.NewV1()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidLegacy.String()[:18], "00ab32c2-1494-1c01")
+	verify.Equal(t, uuidLegacy.Version(), uuid.V1)
+	verify.Equal(t, uuidLegacy.Variant(), uuid.VariantRFC4122)
+	ts, err = uuidLegacy.LegacyV1Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now), "legacy v1 time must be generator time")
+
+	// Former package-level UUIDs
+	uuidLegacy, err = uuid.Parse("00ab32c2-1494-1c01-b3c8-9f6bdeced846")
+	verify.NoError(t, err)
+	ts, err = uuidLegacy.LegacyV1Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now), "legacy v1 time must be decoded")
+	_, err = uuid.New().LegacyV1Time()
+	verify.True(t, errors.Is(err, uuid.ErrInvalidVersion))
+}
+
+// TestGeneratorAt tests the generation of UUIDs for given timestamps.
+func TestGeneratorAt(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	past := time.Date(2003, time.March, 17, 9, 15, 42, 123456700, time.UTC)
+	gen := uuid.NewGenerator(uuid.WithClock(func() time.Time { return now }), uuid.WithRandom(&sequenceReader{}))
+
+	// Test v7 does not touch monotonic state
+	uuidA, err := gen.NewV7()
+	verify.NoError(t, err)
+	uuidPast, err := gen.NewV7At(past)
+	verify.NoError(t, err)
+	uuidB, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidB.Counter(), uuidA.Counter()+1)
+
+	verify.Equal(t, uuidPast.Version(), uuid.V7)
+	verify.Equal(t, uuidPast.Variant(), uuid.VariantRFC4122)
+	ts, err := uuidPast.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(past.Truncate(time.Millisecond)), "v7 time must be given time")
+	verify.True(t, uuidPast.Less(uuidA), "past v7 sorts before current")
+
+	// Test v6 does not touch monotonic state
+	uuidA, err = gen.NewV6()
+	verify.NoError(t, err)
+	uuidPast, err = gen.NewV6At(past)
+	verify.NoError(t, err)
+	uuidB, err = gen.NewV6()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidB.ClockSequence(), uuidA.ClockSequence()+1)
+
+	verify.Equal(t, uuidPast.Version(), uuid.V6)
+	verify.Equal(t, uuidPast.Variant(), uuid.VariantRFC4122)
+	verify.Equal(t, uuidPast.NodeID(), uuidA.NodeID())
+	ts, err = uuidPast.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(past), "v6 time must be given time")
+	verify.True(t, uuidPast.Less(uuidA), "past v6 sorts before current")
+
+	// Test v1 does not touch monotonic state
+	uuidA, err = gen.NewV1()
+	verify.NoError(t, err)
+	uuidPast, err = gen.NewV1At(past)
+	verify.NoError(t, err)
+	uuidB, err = gen.NewV1()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidB.ClockSequence(), uuidA.ClockSequence()+1)
+
+	verify.Equal(t, uuidPast.Version(), uuid.V1)
+	verify.Equal(t, uuidPast.Variant(), uuid.VariantRFC4122)
+	ts, err = uuidPast.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(past), "v1 time must be given time")
+
+	// Test package-level function creates unique v7 UUIDs
+	seen := make(map[uuid.UUID]bool)
+	for range 10000 {
+		u, err := uuid.NewV7At(past)
+		verify.NoError(t, err)
+		verify.False(t, seen[u], "UUIDs for same time must be unique")
+		seen[u] = true
+	}
+}
+
+// TestGeneratorAtRange tests the range of times for UUIDs generated
+// for a given time.
+func TestGeneratorAtRange(t *testing.T) {
+	gen := uuid.NewGenerator()
+	gregorianFirst := time.Date(1582, time.October, 15, 0, 0, 0, 0, time.UTC)
+	gregorianLast := time.Unix((1<<60-1)/10000000-12219292800, (1<<60-1)%10000000*100)
+
+	// Test v7 from 1970 to the end of 48 bits
+	v7Times := []time.Time{
+		time.UnixMilli(0),
+		time.UnixMilli(1<<48 - 1),
+	}
+	for _, at := range v7Times {
+		u, err := gen.NewV7At(at)
+		verify.NoError(t, err)
+		ts, err := u.Time()
+		verify.NoError(t, err)
+		verify.True(t, ts.Equal(at), "v7 time must be given time")
+	}
+	v7Invalid := []time.Time{
+		time.UnixMilli(-1),
+		time.UnixMilli(1 << 48),
+		time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, at := range v7Invalid {
+		_, err := gen.NewV7At(at)
+		verify.True(t, errors.Is(err, uuid.ErrTimeOutOfRange), at.String())
+	}
+
+	// Test v1 and v6 from 1582 to the end of 60 bits, also beyond the
+	// range of UnixNano
+	gregorianTimes := []time.Time{
+		gregorianFirst,
+		time.Date(1600, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2300, time.January, 1, 0, 0, 0, 0, time.UTC),
+		gregorianLast,
+	}
+	for _, at := range gregorianTimes {
+		u, err := gen.NewV6At(at)
+		verify.NoError(t, err)
+		ts, err := u.Time()
+		verify.NoError(t, err)
+		verify.True(t, ts.Equal(at), "v6 time must be given time")
+		u, err = gen.NewV1At(at)
+		verify.NoError(t, err)
+		ts, err = u.Time()
+		verify.NoError(t, err)
+		verify.True(t, ts.Equal(at), "v1 time must be given time")
+	}
+	gregorianInvalid := []time.Time{
+		gregorianFirst.Add(-100 * time.Nanosecond),
+		gregorianLast.Add(100 * time.Nanosecond),
+		time.Date(1500, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, at := range gregorianInvalid {
+		_, err := gen.NewV6At(at)
+		verify.True(t, errors.Is(err, uuid.ErrTimeOutOfRange), at.String())
+		_, err = gen.NewV1At(at)
+		verify.True(t, errors.Is(err, uuid.ErrTimeOutOfRange), at.String())
+	}
+}
+
+// TestGeneratorV7Methods tests the different methods for monotonic
+// v7 UUIDs.
+func TestGeneratorV7Methods(t *testing.T) {
+	start := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	methods := []struct {
+		name   string
+		method uuid.V7Method
+	}{
+		{"counter", uuid.V7Counter},
+		{"long-counter", uuid.V7LongCounter},
+		{"sub-millisecond", uuid.V7SubMillisecond},
+		{"monotonic-random", uuid.V7MonotonicRandom},
+	}
+
+	for _, method := range methods {
+		t.Run(method.name, func(t *testing.T) {
+			gen := uuid.NewGenerator(
+				uuid.WithClock(steppingClock(start, 10*time.Microsecond)),
+				uuid.WithV7Method(method.method),
+			)
+			prev := uuid.Nil
+			for range 10000 {
+				u, err := gen.NewV7()
+				verify.NoError(t, err)
+				verify.Equal(t, u.Version(), uuid.V7)
+				verify.Equal(t, u.Variant(), uuid.VariantRFC4122)
+				if !prev.Less(u) {
+					t.Fatalf("UUID %v not greater than previous %v", u, prev)
+				}
+				prev = u
+			}
+			ts, err := prev.Time()
+			verify.NoError(t, err)
+			verify.False(t, ts.Before(start.Add(100*time.Millisecond)), "last UUID not before 100ms")
+		})
+	}
+}
+
+// TestGeneratorV7HighThroughput tests methods which allow more than 4096
+// UUIDs within the same millisecond without blocking.
+func TestGeneratorV7HighThroughput(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	for _, method := range []uuid.V7Method{uuid.V7LongCounter, uuid.V7MonotonicRandom} {
+		gen := uuid.NewGenerator(uuid.WithClock(clock), uuid.WithV7Method(method))
+		prev := uuid.Nil
+		for range 100000 {
+			u, err := gen.NewV7()
+			verify.NoError(t, err)
+			if !prev.Less(u) {
+				t.Fatalf("UUID %v not greater than previous %v", u, prev)
+			}
+			prev = u
+		}
+		ts, err := prev.Time()
+		verify.NoError(t, err)
+		verify.True(t, ts.Equal(now), "all UUIDs in same millisecond")
+	}
+
+	// The long counter counts exactly by one
+	gen := uuid.NewGenerator(uuid.WithClock(clock), uuid.WithV7Method(uuid.V7LongCounter))
+	uuidA, err := gen.NewV7()
+	verify.NoError(t, err)
+	uuidB, err := gen.NewV7()
+	verify.NoError(t, err)
+	counter := func(u uuid.UUID) uint64 {
+		return uint64(u.Counter())<<30 | uint64(u[8]&0x3f)<<24 | uint64(u[9])<<16 | uint64(u[10])<<8 | uint64(u[11])
+	}
+	verify.Equal(t, counter(uuidB), counter(uuidA)+1)
+}
+
+// TestGeneratorV7SubMillisecond tests the additional clock precision.
+func TestGeneratorV7SubMillisecond(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 500000, time.UTC)
+	gen := uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithV7Method(uuid.V7SubMillisecond),
+	)
+
+	// Half a millisecond is 2048, then incremented for the same time
+	uuidA, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidA.Counter(), uint16(2048))
+	uuidB, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidB.Counter(), uint16(2049))
+
+	// Advancing clock is reflected
+	now = now.Add(250 * time.Microsecond)
+	uuidC, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidC.Counter(), uint16(3072))
+}
+
+// TestGeneratorV7DriftLimit tests borrowing time from the future instead
+// of blocking under counter overflow.
+func TestGeneratorV7DriftLimit(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	gen := uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithV7DriftLimit(5*time.Millisecond),
+	)
+
+	// Fixed clock exhausts the counters until drift limit is reached
+	prev := uuid.Nil
+	count := 0
+	for {
+		u, err := gen.NewV7()
+		if err != nil {
+			verify.True(t, errors.Is(err, uuid.ErrClockDriftExceeded))
+			break
+		}
+		if !prev.Less(u) {
+			t.Fatalf("UUID %v not greater than previous %v", u, prev)
+		}
+		prev = u
+		count++
+	}
+	verify.True(t, count >= 6, "at least one UUID in six milliseconds")
+	verify.True(t, count <= 6*4096, "at most six full milliseconds")
+	ts, err := prev.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now.Add(5*time.Millisecond)), "last UUID at drift limit")
+
+	// Error does not change the state
+	_, err = gen.NewV7()
+	verify.True(t, errors.Is(err, uuid.ErrClockDriftExceeded))
+
+	// Advancing clock allows generation again
+	now = now.Add(10 * time.Millisecond)
+	u, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.True(t, prev.Less(u))
+	ts, err = u.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now), "UUID at real time again")
+
+	// Clock going backwards within the limit is compensated
+	now = now.Add(-3 * time.Millisecond)
+	u, err = gen.NewV7()
+	verify.NoError(t, err)
+	verify.True(t, prev.Less(u))
+	prev = u
+
+	// Clock going backwards beyond the limit is an error
+	now = now.Add(-10 * time.Millisecond)
+	_, err = gen.NewV7()
+	verify.True(t, errors.Is(err, uuid.ErrClockDriftExceeded))
+	now = now.Add(13 * time.Millisecond)
+	u, err = gen.NewV7()
+	verify.NoError(t, err)
+	verify.True(t, prev.Less(u))
+}
+
+// TestGeneratorV2 tests the clock sequences of Version 2 UUIDs per
+// domain and id.
+func TestGeneratorV2(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	gen := uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithRandom(&sequenceReader{}),
+		uuid.WithNode([6]byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}),
+	)
+
+	// All 64 clock sequences are unique, then they are exhausted.
+	seen := map[uuid.UUID]bool{}
+	seqs := map[uint16]bool{}
+	for range 64 {
+		u, err := gen.NewV2(uuid.Person, 1000)
+		verify.NoError(t, err)
+		verify.Equal(t, u.Version(), uuid.V2)
+		verify.Equal(t, u.Variant(), uuid.VariantRFC4122)
+		verify.Equal(t, u.Domain(), uuid.Person)
+		verify.Equal(t, u.ID(), uint32(1000))
+		verify.False(t, seen[u], "v2 UUID must be unique")
+		seen[u] = true
+		seqs[u.ClockSequence()] = true
+	}
+	verify.Length(t, seqs, 64)
+	_, err := gen.NewV2(uuid.Person, 1000)
+	verify.True(t, errors.Is(err, uuid.ErrV2Exhausted))
+
+	// Other domain and id pairs have their own clock sequences.
+	_, err = gen.NewV2(uuid.Group, 1000)
+	verify.NoError(t, err)
+	_, err = gen.NewV2(uuid.Person, 1001)
+	verify.NoError(t, err)
+
+	// The truncated time is not after the clock and at most about
+	// 7 minutes before.
+	u, err := gen.NewV2(uuid.Org, 1)
+	verify.NoError(t, err)
+	ts, err := u.Time()
+	verify.NoError(t, err)
+	verify.False(t, ts.After(now), "truncated time must not be after the clock")
+	verify.True(t, now.Sub(ts) < 430*time.Second, "truncated time must be within 7 minutes")
+
+	// The next truncated timestamp releases the clock sequences.
+	former := now
+	now = now.Add(430 * time.Second)
+	u, err = gen.NewV2(uuid.Person, 1000)
+	verify.NoError(t, err)
+	next, err := u.Time()
+	verify.NoError(t, err)
+	verify.True(t, next.After(ts), "truncated time must advance")
+
+	// A clock set back stays with the last truncated timestamp.
+	now = former
+	u, err = gen.NewV2(uuid.Person, 1000)
+	verify.NoError(t, err)
+	back, err := u.Time()
+	verify.NoError(t, err)
+	verify.True(t, back.Equal(next), "truncated time must not go back")
+}
+
+// TestGeneratorV2Entries tests the limit of domain and id pairs within
+// a truncated timestamp.
+func TestGeneratorV2Entries(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	gen := uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithRandom(&sequenceReader{}),
+	)
+
+	for id := range uint32(1 << 16) {
+		_, err := gen.NewV2(uuid.Person, id)
+		verify.NoError(t, err)
+	}
+	_, err := gen.NewV2(uuid.Person, 1<<16)
+	verify.True(t, errors.Is(err, uuid.ErrV2Exhausted))
+	_, err = gen.NewV2(uuid.Person, 1)
+	verify.NoError(t, err)
+
+	// The next truncated timestamp releases the entries.
+	now = now.Add(430 * time.Second)
+	_, err = gen.NewV2(uuid.Person, 1<<16)
+	verify.NoError(t, err)
+}
+
+// TestGeneratorFixedClock tests that a standing clock does not block
+// the generation when the counters overflow.
+func TestGeneratorFixedClock(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	gen := uuid.NewGenerator(uuid.WithClock(func() time.Time { return now }))
+
+	// Without drift limit v7 waits for the clock, but not forever
+	prev := uuid.Nil
+	var err error
+	for err == nil {
+		var u uuid.UUID
+		if u, err = gen.NewV7(); err == nil {
+			verify.True(t, prev.Less(u), "v7 must be increasing")
+			prev = u
+		}
+	}
+	verify.True(t, errors.Is(err, uuid.ErrClockStalled))
+	ts, err := prev.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now), "v7 stays at the clock")
+
+	// Advancing clock allows generation again
+	now = now.Add(time.Millisecond)
+	u, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.True(t, prev.Less(u), "v7 must be increasing")
+
+	prev = uuid.Nil
+	for range 20000 {
+		u, err := gen.NewV6()
+		verify.NoError(t, err)
+		verify.True(t, prev.Less(u), "v6 must be increasing")
+		prev = u
+	}
+	ts, err = prev.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.After(now), "v6 continues ahead of the clock")
+}
+
+// TestGeneratorV7Wait tests that v7 generation waits for the next
+// millisecond when the counter overflows.
+func TestGeneratorV7Wait(t *testing.T) {
+	start := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	var offset atomic.Int64
+	gen := uuid.NewGenerator(uuid.WithClock(func() time.Time {
+		return start.Add(time.Duration(offset.Load()))
+	}))
+
+	// Exhaust the counter of the first millisecond
+	prev := uuid.Nil
+	for {
+		u, err := gen.NewV7()
+		verify.NoError(t, err)
+		ts, err := u.Time()
+		verify.NoError(t, err)
+		if !ts.Equal(start) {
+			t.Fatalf("UUID %v not in the first millisecond", u)
+		}
+		prev = u
+		if u.Counter() == 0x0fff {
+			break
+		}
+	}
+
+	// Next UUID waits until the clock advances
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		offset.Store(int64(time.Millisecond))
+	}()
+	u, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.True(t, prev.Less(u), "v7 must be increasing")
+	ts, err := u.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(start.Add(time.Millisecond)), "v7 at the advanced clock")
+}
+
+// Helpers
+
+// steppingClock returns a clock advancing by step with each call.
+func steppingClock(start time.Time, step time.Duration) func() time.Time {
+	now := start
+	return func() time.Time {
+		now = now.Add(step)
+		return now
+	}
+}
+
+// sequenceReader is a deterministic entropy source.
+type sequenceReader struct {
+	next byte
+}
+
+func (r *sequenceReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.next
+		r.next++
+	}
+	return len(p), nil
+}
+
+// failingReader is an always failing entropy source.
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("entropy exhausted")
+}
+
+// recoveringReader is an entropy source failing until it is recovered.
+type recoveringReader struct {
+	sequenceReader
+	failing bool
+}
+
+func (r *recoveringReader) Read(p []byte) (int, error) {
+	if r.failing {
+		return 0, errors.New("entropy exhausted")
+	}
+	return r.sequenceReader.Read(p)
+}
+
+// EOF
@@ -0,0 +1,261 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"database/sql/driver"
+	"time"
+)
+
+//--------------------
+// GENERIC ID
+//--------------------
+
+// ID is a UUID typed by the entity it identifies. So ID[User] and
+// ID[Order] are distinct types and cannot be mixed up, while both
+// share the implementation of UUID. The type parameter is only used
+// as marker, e.g.
+//
+//	type User struct{ ID uuid.ID[User] }
+//
+//	func FindUser(id uuid.ID[User]) (*User, error)
+type ID[T any] UUID
+
+// NewID creates a new ID based on a Version 7 UUID of the default
+// generator.
+func NewID[T any]() (ID[T], error) {
+	uuid, err := NewV7()
+	return ID[T](uuid), err
+}
+
+// IDFrom types an existing UUID.
+func IDFrom[T any](uuid UUID) ID[T] {
+	return ID[T](uuid)
+}
+
+// ParseID parses an ID in all formats supported by Parse.
+func ParseID[T any](source string) (ID[T], error) {
+	uuid, err := Parse(source)
+	return ID[T](uuid), err
+}
+
+// CompareIDs returns an integer comparing two IDs like Compare,
+// e.g. for sorting with slices.SortFunc.
+func CompareIDs[T any](a, b ID[T]) int {
+	return Compare(UUID(a), UUID(b))
+}
+
+// UUID returns the untyped UUID.
+func (id ID[T]) UUID() UUID {
+	return UUID(id)
+}
+
+// Version returns the version of the ID.
+func (id ID[T]) Version() Version {
+	return UUID(id).Version()
+}
+
+// Variant returns the variant of the ID.
+func (id ID[T]) Variant() Variant {
+	return UUID(id).Variant()
+}
+
+// IsNil returns true if the ID is the Nil UUID.
+func (id ID[T]) IsNil() bool {
+	return UUID(id).IsNil()
+}
+
+// IsMax returns true if the ID is the Max UUID.
+func (id ID[T]) IsMax() bool {
+	return UUID(id).IsMax()
+}
+
+// Time returns the timestamp of a time-based ID, see UUID.Time.
+func (id ID[T]) Time() (time.Time, error) {
+	return UUID(id).Time()
+}
+
+// LegacyV1Time returns the timestamp of a legacy Version 1 ID, see
+// UUID.LegacyV1Time.
+func (id ID[T]) LegacyV1Time() (time.Time, error) {
+	return UUID(id).LegacyV1Time()
+}
+
+// Domain returns the domain of a Version 2 ID.
+func (id ID[T]) Domain() Domain {
+	return UUID(id).Domain()
+}
+
+// ID returns the local identifier of a Version 2 ID.
+func (id ID[T]) ID() uint32 {
+	return UUID(id).ID()
+}
+
+// V8Fields returns the custom fields of a Version 8 ID, see
+// UUID.V8Fields.
+func (id ID[T]) V8Fields() (customA uint64, customB uint16, customC uint64) {
+	return UUID(id).V8Fields()
+}
+
+// ClockSequence returns the clock sequence of a time-based ID, see
+// UUID.ClockSequence.
+func (id ID[T]) ClockSequence() uint16 {
+	return UUID(id).ClockSequence()
+}
+
+// NodeID returns the node identifier of a time-based ID.
+func (id ID[T]) NodeID() [6]byte {
+	return UUID(id).NodeID()
+}
+
+// Counter returns the rand_a field of a Version 7 ID, see UUID.Counter.
+func (id ID[T]) Counter() uint16 {
+	return UUID(id).Counter()
+}
+
+// ToV6 converts a Version 1 ID into a Version 6 ID, see UUID.ToV6.
+func (id ID[T]) ToV6() (ID[T], error) {
+	uuid, err := UUID(id).ToV6()
+	return ID[T](uuid), err
+}
+
+// ToV1 converts a Version 6 ID into a Version 1 ID, see UUID.ToV1.
+func (id ID[T]) ToV1() (ID[T], error) {
+	uuid, err := UUID(id).ToV1()
+	return ID[T](uuid), err
+}
+
+// Compare returns an integer comparing the ID with the other one.
+func (id ID[T]) Compare(other ID[T]) int {
+	return Compare(UUID(id), UUID(other))
+}
+
+// Less returns true if the ID is ordered before the other one.
+func (id ID[T]) Less(other ID[T]) bool {
+	return UUID(id).Less(UUID(other))
+}
+
+// Equal returns true if both IDs are equal.
+func (id ID[T]) Equal(other ID[T]) bool {
+	return UUID(id).Equal(UUID(other))
+}
+
+// String returns the canonical string representation of the ID.
+func (id ID[T]) String() string {
+	return UUID(id).String()
+}
+
+// ShortString returns the string representation without dashes.
+func (id ID[T]) ShortString() string {
+	return UUID(id).ShortString()
+}
+
+// AppendString appends the canonical string representation of the ID
+// to dst.
+func (id ID[T]) AppendString(dst []byte) []byte {
+	return UUID(id).AppendString(dst)
+}
+
+// AppendShortString appends the string representation without dashes
+// to dst.
+func (id ID[T]) AppendShortString(dst []byte) []byte {
+	return UUID(id).AppendShortString(dst)
+}
+
+// Copy returns a copy of the ID.
+func (id ID[T]) Copy() ID[T] {
+	return id
+}
+
+// Raw returns the ID as byte array.
+func (id ID[T]) Raw() [16]byte {
+	return UUID(id).Raw()
+}
+
+// EncodeBase32 returns the ID in Crockford Base32, see UUID.EncodeBase32.
+func (id ID[T]) EncodeBase32() string {
+	return UUID(id).EncodeBase32()
+}
+
+// EncodeBase58 returns the ID in Base58, see UUID.EncodeBase58.
+func (id ID[T]) EncodeBase58() string {
+	return UUID(id).EncodeBase58()
+}
+
+// EncodeBase62 returns the ID in Base62, see UUID.EncodeBase62.
+func (id ID[T]) EncodeBase62() string {
+	return UUID(id).EncodeBase62()
+}
+
+// EncodeBase64URL returns the ID in URL-safe Base64, see
+// UUID.EncodeBase64URL.
+func (id ID[T]) EncodeBase64URL() string {
+	return UUID(id).EncodeBase64URL()
+}
+
+// ULID returns a Version 7 ID as ULID string, see UUID.ULID.
+func (id ID[T]) ULID() (string, error) {
+	return UUID(id).ULID()
+}
+
+// AppendText implements encoding.TextAppender.
+func (id ID[T]) AppendText(b []byte) ([]byte, error) {
+	return UUID(id).AppendText(b)
+}
+
+// MarshalText implements encoding.TextMarshaler, so IDs can be
+// used as JSON values as well as JSON map keys.
+func (id ID[T]) MarshalText() ([]byte, error) {
+	return UUID(id).MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (id *ID[T]) UnmarshalText(text []byte) error {
+	return (*UUID)(id).UnmarshalText(text)
+}
+
+// Scan implements sql.Scanner, see UUID.Scan.
+func (id *ID[T]) Scan(src any) error {
+	return (*UUID)(id).Scan(src)
+}
+
+// Value implements driver.Valuer, see UUID.Value.
+func (id ID[T]) Value() (driver.Value, error) {
+	return UUID(id).Value()
+}
+
+//--------------------
+// NULLABLE GENERIC ID
+//--------------------
+
+// NullID represents an ID that may be SQL NULL. It can be used like
+// NullUUID for nullable columns.
+type NullID[T any] struct {
+	ID    ID[T]
+	Valid bool // Valid is true if ID is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (nid *NullID[T]) Scan(src any) error {
+	var nu NullUUID
+	err := nu.Scan(src)
+	nid.ID, nid.Valid = ID[T](nu.UUID), nu.Valid
+	return err
+}
+
+// Value implements driver.Valuer. An invalid NullID is stored as
+// SQL NULL.
+func (nid NullID[T]) Value() (driver.Value, error) {
+	return NullUUID{UUID: UUID(nid.ID), Valid: nid.Valid}.Value()
+}
+
+// EOF
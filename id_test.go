@@ -0,0 +1,156 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestID tests the typed IDs.
+func TestID(t *testing.T) {
+	u, err := uuid.Parse("017F22E2-79B0-7CC3-98C4-DC0C0C07398F")
+	verify.NoError(t, err)
+	id := uuid.IDFrom[user](u)
+	verify.Equal(t, id.UUID(), u)
+	verify.Equal(t, id.String(), u.String())
+	verify.Equal(t, id.ShortString(), u.ShortString())
+	verify.Equal(t, id.Version(), uuid.V7)
+	verify.Equal(t, id.Variant(), uuid.VariantRFC4122)
+	verify.False(t, id.IsNil(), "ID must not be nil")
+	ts, err := id.Time()
+	verify.NoError(t, err)
+	verify.Equal(t, ts.UnixMilli(), int64(0x017F22E279B0))
+
+	// Other methods are forwarded to the UUID.
+	verify.False(t, id.IsMax(), "ID must not be max")
+	verify.Equal(t, id.Raw(), u.Raw())
+	verify.Equal(t, id.Copy(), id)
+	verify.Equal(t, id.Counter(), u.Counter())
+	verify.Equal(t, string(id.AppendString(nil)), u.String())
+	verify.Equal(t, string(id.AppendShortString(nil)), u.ShortString())
+	verify.Equal(t, id.EncodeBase32(), u.EncodeBase32())
+	verify.Equal(t, id.EncodeBase58(), u.EncodeBase58())
+	verify.Equal(t, id.EncodeBase62(), u.EncodeBase62())
+	verify.Equal(t, id.EncodeBase64URL(), u.EncodeBase64URL())
+	ulid, err := id.ULID()
+	verify.NoError(t, err)
+	verify.Equal(t, ulid, "01FWHE4YDGFK1SHH6W1G60EECF")
+
+	parsed, err := uuid.ParseID[user](id.String())
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, id)
+	_, err = uuid.ParseID[user]("invalid")
+	verify.Error(t, err)
+
+	// New IDs are ordered like v7 UUIDs.
+	ids := make([]uuid.ID[order], 100)
+	for i := range ids {
+		ids[i], err = uuid.NewID[order]()
+		verify.NoError(t, err)
+	}
+	verify.True(t, slices.IsSortedFunc(ids, uuid.CompareIDs[order]), "IDs must be sorted")
+	verify.True(t, ids[0].Less(ids[1]), "first ID must be less")
+	verify.Equal(t, ids[1].Compare(ids[0]), 1)
+	verify.True(t, ids[0].Equal(ids[0]), "ID must equal itself")
+}
+
+// TestIDTimeBased tests the time-based fields and conversions of
+// typed IDs.
+func TestIDTimeBased(t *testing.T) {
+	u, err := uuid.NewV1()
+	verify.NoError(t, err)
+	id := uuid.IDFrom[user](u)
+	verify.Equal(t, id.ClockSequence(), u.ClockSequence())
+	verify.Equal(t, id.NodeID(), u.NodeID())
+
+	idV6, err := id.ToV6()
+	verify.NoError(t, err)
+	verify.Equal(t, idV6.Version(), uuid.V6)
+	idV1, err := idV6.ToV1()
+	verify.NoError(t, err)
+	verify.Equal(t, idV1, id)
+	_, err = idV1.ToV1()
+	verify.True(t, err != nil, "v1 cannot be converted to v1")
+
+	u, err = uuid.NewV2(uuid.Person, 1000)
+	verify.NoError(t, err)
+	id = uuid.IDFrom[user](u)
+	verify.Equal(t, id.Domain(), uuid.Person)
+	verify.Equal(t, id.ID(), uint32(1000))
+
+	u = uuid.NewV8Fields(1, 2, 3)
+	customA, customB, customC := uuid.IDFrom[user](u).V8Fields()
+	verify.Equal(t, customA, uint64(1))
+	verify.Equal(t, customB, uint16(2))
+	verify.Equal(t, customC, uint64(3))
+}
+
+// TestIDMarshalling tests JSON and SQL support of typed IDs.
+func TestIDMarshalling(t *testing.T) {
+	type account struct {
+		ID     uuid.ID[user]                `json:"id"`
+		Orders map[uuid.ID[order]]uuid.UUID `json:"orders"`
+	}
+	orderID, err := uuid.NewID[order]()
+	verify.NoError(t, err)
+	userID, err := uuid.NewID[user]()
+	verify.NoError(t, err)
+	in := account{
+		ID:     userID,
+		Orders: map[uuid.ID[order]]uuid.UUID{orderID: orderID.UUID()},
+	}
+	data, err := json.Marshal(in)
+	verify.NoError(t, err)
+	var out account
+	verify.NoError(t, json.Unmarshal(data, &out))
+	verify.Equal(t, out.ID, in.ID)
+	verify.Equal(t, out.Orders[orderID], orderID.UUID())
+
+	value, err := userID.Value()
+	verify.NoError(t, err)
+	verify.Equal(t, value, any(userID.String()))
+	var scanned uuid.ID[user]
+	verify.NoError(t, scanned.Scan(value))
+	verify.Equal(t, scanned, userID)
+	verify.Error(t, scanned.Scan(nil))
+
+	// Nullable IDs store invalid ones as NULL.
+	var null uuid.NullID[user]
+	verify.NoError(t, null.Scan(value))
+	verify.True(t, null.Valid, "scanned ID must be valid")
+	verify.Equal(t, null.ID, userID)
+	value, err = null.Value()
+	verify.NoError(t, err)
+	verify.Equal(t, value, any(userID.String()))
+	verify.NoError(t, null.Scan(nil))
+	verify.False(t, null.Valid, "NULL must be invalid")
+	verify.True(t, null.ID.IsNil(), "NULL must be nil")
+	value, err = null.Value()
+	verify.NoError(t, err)
+	verify.Nil(t, value)
+	verify.Error(t, null.Scan("invalid"))
+	verify.False(t, null.Valid, "invalid ID must be invalid")
+}
+
+// Helpers
+
+// user and order are entities for typed IDs.
+type (
+	user  struct{}
+	order struct{}
+)
+
+// EOF
@@ -0,0 +1,38 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// TEXT MARSHALLING
+//--------------------
+
+// AppendText implements encoding.TextAppender. It appends the
+// canonical string representation of the UUID to b.
+func (uuid UUID) AppendText(b []byte) ([]byte, error) {
+	return uuid.AppendString(b), nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The UUID is
+// marshalled in its canonical string representation, so it can
+// be used as JSON value as well as JSON map key.
+func (uuid UUID) MarshalText() ([]byte, error) {
+	return uuid.AppendText(make([]byte, 0, 36))
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. All formats
+// accepted by Parse are valid.
+func (uuid *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*uuid = parsed
+	return nil
+}
+
+// EOF
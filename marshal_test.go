@@ -0,0 +1,75 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestTextMarshalling tests marshalling and unmarshalling as text.
+func TestTextMarshalling(t *testing.T) {
+	u := uuid.New()
+
+	text, err := u.MarshalText()
+	verify.NoError(t, err)
+	verify.Equal(t, string(text), u.String())
+
+	appended, err := u.AppendText([]byte("id="))
+	verify.NoError(t, err)
+	verify.Equal(t, string(appended), "id="+u.String())
+
+	var parsed uuid.UUID
+	err = parsed.UnmarshalText(text)
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, u)
+
+	err = parsed.UnmarshalText([]byte("urn:uuid:" + u.String()))
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, u)
+
+	err = parsed.UnmarshalText([]byte("no-uuid"))
+	verify.ErrorContains(t, err, "invalid source format")
+}
+
+// TestJSON tests the JSON round-trip of UUIDs as values and map keys.
+func TestJSON(t *testing.T) {
+	type record struct {
+		ID    uuid.UUID            `json:"id"`
+		Links map[uuid.UUID]string `json:"links"`
+	}
+
+	id := uuid.New()
+	link := uuid.New()
+	in := record{
+		ID:    id,
+		Links: map[uuid.UUID]string{link: "parent"},
+	}
+
+	data, err := json.Marshal(in)
+	verify.NoError(t, err)
+	verify.Contains(t, string(data), `"id":"`+id.String()+`"`)
+	verify.Contains(t, string(data), `"`+link.String()+`":"parent"`)
+
+	var out record
+	err = json.Unmarshal(data, &out)
+	verify.NoError(t, err)
+	verify.Equal(t, out.ID, id)
+	verify.Equal(t, out.Links[link], "parent")
+
+	err = json.Unmarshal([]byte(`{"id":"invalid"}`), &out)
+	verify.Error(t, err)
+}
+
+// EOF
@@ -0,0 +1,227 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+)
+
+//--------------------
+// NODE SOURCE
+//--------------------
+
+// NodeSource describes where the node identifier of Version 1, 2, and 6
+// UUIDs comes from.
+type NodeSource int
+
+// Sources of the node identifier.
+const (
+	// NodeHardware is the MAC address of the first network interface
+	// having one. It is the default.
+	NodeHardware NodeSource = iota
+
+	// NodeRandom is a random node with the multicast bit set as described
+	// in RFC 9562 Section 6.10. It is also used if the wanted source
+	// is not available.
+	NodeRandom
+
+	// NodeConfigured is a node set explicitly with WithNode.
+	NodeConfigured
+
+	// NodeHostname is derived from the SHA-256 hash of the hostname
+	// with the multicast bit set.
+	NodeHostname
+
+	// NodeName is derived from the SHA-256 hash of a given name, e.g.
+	// a Kubernetes pod UID, with the multicast bit set.
+	NodeName
+)
+
+// String returns the string representation of a NodeSource.
+func (s NodeSource) String() string {
+	switch s {
+	case NodeHardware:
+		return "Hardware"
+	case NodeRandom:
+		return "Random"
+	case NodeConfigured:
+		return "Configured"
+	case NodeHostname:
+		return "Hostname"
+	case NodeName:
+		return "Name"
+	}
+	return fmt.Sprintf("NodeSource%d", int(s))
+}
+
+//--------------------
+// NODE RESOLUTION
+//--------------------
+
+// nodeState holds the node identifier of a Generator. It is resolved
+// lazily with the first time-based UUID. The source is the wanted one,
+// used the one the node has been resolved from.
+type nodeState struct {
+	mu       sync.RWMutex
+	private  bool
+	source   NodeSource
+	name     string
+	resolved bool
+	used     NodeSource
+	node     [6]byte
+	err      error
+}
+
+// Node returns the node identifier used for Version 1, 2, and 6 UUIDs
+// and its source. If the wanted source has not been available the
+// source is NodeRandom. The node is determined with the first call
+// of this method or the first generation of a time-based UUID.
+func (g *Generator) Node() ([6]byte, NodeSource, error) {
+	node, err := g.currentNode()
+	g.node.mu.RLock()
+	defer g.node.mu.RUnlock()
+	return node, g.node.used, err
+}
+
+// RotateNode replaces the node identifier by a new random one with the
+// multicast bit set. Afterwards the source is NodeRandom. Regular rotation
+// in privacy mode keeps UUIDs of different periods from being correlated.
+func (g *Generator) RotateNode() error {
+	node, err := g.randomNode()
+	if err != nil {
+		return err
+	}
+	g.node.mu.Lock()
+	defer g.node.mu.Unlock()
+	g.node.resolved = true
+	g.node.used = NodeRandom
+	g.node.node = node
+	g.node.err = nil
+	return nil
+}
+
+// RotateNode replaces the node identifier of the default generator by a
+// new random one. See Generator.RotateNode.
+func RotateNode() error {
+	return DefaultGenerator().RotateNode()
+}
+
+// currentNode returns the resolved node identifier.
+func (g *Generator) currentNode() ([6]byte, error) {
+	g.resolveNode()
+	g.node.mu.RLock()
+	defer g.node.mu.RUnlock()
+	if g.node.err != nil {
+		return [6]byte{}, g.node.err
+	}
+	return g.node.node, nil
+}
+
+// resolveNode determines the node identifier once. A failed resolution
+// is not kept, it is retried with the next call.
+func (g *Generator) resolveNode() {
+	g.node.mu.RLock()
+	resolved := g.node.resolved
+	g.node.mu.RUnlock()
+	if resolved {
+		return
+	}
+
+	g.node.mu.Lock()
+	defer g.node.mu.Unlock()
+	if g.node.resolved {
+		return
+	}
+	source := g.node.source
+	if g.node.private {
+		// Privacy mode never looks at the hardware.
+		source = NodeRandom
+	}
+	g.node.resolved, g.node.used, g.node.err = true, source, nil
+	switch source {
+	case NodeConfigured:
+		return
+	case NodeHardware:
+		if address, ok := hardwareAddress(); ok {
+			g.node.node = address
+			return
+		}
+	case NodeHostname:
+		if hostname, err := os.Hostname(); err == nil {
+			g.node.node = hashedNode(hostname)
+			return
+		}
+	case NodeName:
+		g.node.node = hashedNode(g.node.name)
+		return
+	}
+	// Fall back to a random node.
+	g.node.used = NodeRandom
+	g.node.node, g.node.err = g.randomNode()
+	g.node.resolved = g.node.err == nil
+}
+
+// randomNode creates a random node with the multicast bit set as
+// described in RFC 9562 Section 6.10.
+func (g *Generator) randomNode() ([6]byte, error) {
+	node := [6]byte{}
+	if err := g.read(node[:]); err != nil {
+		return node, fmt.Errorf("cannot create random node: %w", err)
+	}
+	node[0] |= 0x01
+	return node, nil
+}
+
+// hashedNode derives a node from the SHA-256 hash of a name and sets
+// the multicast bit to avoid conflicts with real MAC addresses.
+func hashedNode(name string) [6]byte {
+	hash := sha256.Sum256([]byte(name))
+	node := [6]byte(hash[:6])
+	node[0] |= 0x01
+	return node
+}
+
+var (
+	hardwareMu      sync.Mutex
+	hardwareDone    bool
+	hardwareNode    [6]byte
+	hardwareNodeSet bool
+)
+
+// hardwareAddress retrieves the MAC address of the computer once. If
+// the interfaces cannot be read the lookup is retried with the next call.
+func hardwareAddress() ([6]byte, bool) {
+	hardwareMu.Lock()
+	defer hardwareMu.Unlock()
+	if hardwareDone {
+		return hardwareNode, hardwareNodeSet
+	}
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return [6]byte{}, false
+	}
+	hardwareDone = true
+	for _, iface := range ifaces {
+		if len(iface.HardwareAddr) >= 6 {
+			copy(hardwareNode[:], iface.HardwareAddr)
+			hardwareNodeSet = true
+			break
+		}
+	}
+	return hardwareNode, hardwareNodeSet
+}
+
+// EOF
@@ -0,0 +1,162 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestNodeSources tests the different sources of the node identifier.
+func TestNodeSources(t *testing.T) {
+	configured := [6]byte{0x02, 0x42, 0xac, 0x11, 0x00, 0x02}
+	gen := uuid.NewGenerator(uuid.WithNode(configured))
+	node, source, err := gen.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, source, uuid.NodeConfigured)
+	verify.Equal(t, node, configured)
+
+	// Random nodes have the multicast bit set.
+	gen = uuid.NewGenerator(uuid.WithRandomNode(), uuid.WithRandom(&sequenceReader{}))
+	node, source, err = gen.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, source, uuid.NodeRandom)
+	verify.Equal(t, node[0]&0x01, byte(0x01))
+
+	// Name based nodes are stable and have the multicast bit set.
+	podUID := "6f1c5a3e-8a3b-4c55-9d2e-1b7f0e9a4c21"
+	genA := uuid.NewGenerator(uuid.WithNameNode(podUID))
+	genB := uuid.NewGenerator(uuid.WithNameNode(podUID))
+	nodeA, source, err := genA.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, source, uuid.NodeName)
+	verify.Equal(t, nodeA[0]&0x01, byte(0x01))
+	nodeB, _, err := genB.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, nodeA, nodeB)
+	nodeC, _, err := uuid.NewGenerator(uuid.WithNameNode("other-pod")).Node()
+	verify.NoError(t, err)
+	verify.Different(t, nodeA, nodeC)
+
+	// Hostname nodes fall back to random ones.
+	node, source, err = uuid.NewGenerator(uuid.WithHostnameNode()).Node()
+	verify.NoError(t, err)
+	verify.True(t, source == uuid.NodeHostname || source == uuid.NodeRandom, "hostname or fallback")
+	verify.Equal(t, node[0]&0x01, byte(0x01))
+
+	// Hardware is the default, it falls back to random ones.
+	_, source, err = uuid.NewGenerator().Node()
+	verify.NoError(t, err)
+	verify.True(t, source == uuid.NodeHardware || source == uuid.NodeRandom, "hardware or fallback")
+}
+
+// TestNodeUsage tests that the node is used for all time-based versions.
+func TestNodeUsage(t *testing.T) {
+	gen := uuid.NewGenerator(uuid.WithNameNode("tideland"))
+	node, _, err := gen.Node()
+	verify.NoError(t, err)
+
+	uuidV1, err := gen.NewV1()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV1.NodeID(), node)
+	uuidV2, err := gen.NewV2(uuid.Person, 1000)
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV2.NodeID(), node)
+	uuidV6, err := gen.NewV6()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV6.NodeID(), node)
+}
+
+// TestNodeRandomFailure tests that a failing random source is reported.
+func TestNodeRandomFailure(t *testing.T) {
+	gen := uuid.NewGenerator(uuid.WithRandomNode(), uuid.WithRandom(failingReader{}))
+	_, source, err := gen.Node()
+	verify.Error(t, err)
+	verify.Equal(t, source, uuid.NodeRandom)
+	_, err = gen.NewV6()
+	verify.Error(t, err)
+
+	// A failed resolution is retried
+	random := &recoveringReader{failing: true}
+	gen = uuid.NewGenerator(uuid.WithRandomNode(), uuid.WithRandom(random))
+	_, err = gen.NewV1()
+	verify.Error(t, err)
+	random.failing = false
+	u, err := gen.NewV1()
+	verify.NoError(t, err)
+	node, source, err := gen.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, source, uuid.NodeRandom)
+	verify.Equal(t, u.NodeID(), node)
+}
+
+// TestNodePrivacy tests that the privacy mode never uses a real node
+// and that the node can be rotated.
+func TestNodePrivacy(t *testing.T) {
+	configured := [6]byte{0x02, 0x42, 0xac, 0x11, 0x00, 0x02}
+	gen := uuid.NewGenerator(uuid.WithPrivacy(), uuid.WithNode(configured))
+	node, source, err := gen.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, source, uuid.NodeRandom)
+	verify.Different(t, node, configured)
+	verify.Equal(t, node[0]&0x01, byte(0x01))
+
+	uuidV1, err := gen.NewV1()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV1.NodeID(), node)
+
+	// Rotation creates a new random node used from now on.
+	verify.NoError(t, gen.RotateNode())
+	rotated, source, err := gen.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, source, uuid.NodeRandom)
+	verify.Different(t, rotated, node)
+	verify.Equal(t, rotated[0]&0x01, byte(0x01))
+
+	uuidV2, err := gen.NewV2(uuid.Group, 100)
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV2.NodeID(), rotated)
+	uuidV6, err := gen.NewV6()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV6.NodeID(), rotated)
+
+	// Rotation before the first usage is kept.
+	gen = uuid.NewGenerator(uuid.WithNode(configured))
+	verify.NoError(t, gen.RotateNode())
+	node, source, err = gen.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, source, uuid.NodeRandom)
+	verify.Different(t, node, configured)
+
+	// A failing rotation keeps the former node.
+	gen = uuid.NewGenerator(uuid.WithNode(configured), uuid.WithRandom(failingReader{}))
+	verify.Error(t, gen.RotateNode())
+	node, source, err = gen.Node()
+	verify.NoError(t, err)
+	verify.Equal(t, source, uuid.NodeConfigured)
+	verify.Equal(t, node, configured)
+}
+
+// TestDefaultGenerator tests replacing the default generator.
+func TestDefaultGenerator(t *testing.T) {
+	former := uuid.DefaultGenerator()
+	defer uuid.SetDefaultGenerator(former)
+
+	node := [6]byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x01}
+	uuid.SetDefaultGenerator(uuid.NewGenerator(uuid.WithNode(node)))
+	uuidV6, err := uuid.NewV6()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV6.NodeID(), node)
+}
+
+// EOF
@@ -0,0 +1,89 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+//--------------------
+// SQL SUPPORT
+//--------------------
+
+// Scan implements sql.Scanner. It accepts strings in all formats
+// supported by Parse as well as byte slices containing either the
+// 16 raw bytes or the textual representation. SQL NULL cannot be
+// scanned into a UUID, use NullUUID for nullable columns.
+func (uuid *UUID) Scan(src any) error {
+	switch src := src.(type) {
+	case string:
+		parsed, err := Parse(src)
+		if err != nil {
+			return fmt.Errorf("cannot scan string into UUID: %w", err)
+		}
+		*uuid = parsed
+	case []byte:
+		if len(src) == 16 {
+			copy(uuid[:], src)
+			return nil
+		}
+		parsed, err := ParseBytes(src)
+		if err != nil {
+			return fmt.Errorf("cannot scan bytes into UUID: %w", err)
+		}
+		*uuid = parsed
+	case nil:
+		return fmt.Errorf("cannot scan NULL into UUID")
+	default:
+		return fmt.Errorf("cannot scan type %T into UUID", src)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer. The UUID is stored in its
+// canonical string representation.
+func (uuid UUID) Value() (driver.Value, error) {
+	return uuid.String(), nil
+}
+
+// NullUUID represents a UUID that may be SQL NULL. It can be used
+// like sql.NullString for nullable UUID columns.
+type NullUUID struct {
+	UUID  UUID
+	Valid bool // Valid is true if UUID is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (nu *NullUUID) Scan(src any) error {
+	if src == nil {
+		nu.UUID, nu.Valid = UUID{}, false
+		return nil
+	}
+	if err := nu.UUID.Scan(src); err != nil {
+		nu.Valid = false
+		return err
+	}
+	nu.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer. An invalid NullUUID is stored
+// as SQL NULL.
+func (nu NullUUID) Value() (driver.Value, error) {
+	if !nu.Valid {
+		return nil, nil
+	}
+	return nu.UUID.Value()
+}
+
+// EOF
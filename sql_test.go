@@ -0,0 +1,99 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestScan tests scanning UUIDs from database values.
+func TestScan(t *testing.T) {
+	u := uuid.New()
+	raw := u.Raw()
+
+	tests := []struct {
+		name string
+		src  any
+		err  string
+	}{
+		{"string", u.String(), ""},
+		{"string-short", u.ShortString(), ""},
+		{"string-urn", "urn:uuid:" + u.String(), ""},
+		{"bytes-raw", raw[:], ""},
+		{"bytes-text", []byte(u.String()), ""},
+		{"bytes-short", []byte(u.ShortString()), ""},
+		{"null", nil, "cannot scan NULL into UUID"},
+		{"invalid-string", "no-uuid", "cannot scan string into UUID"},
+		{"invalid-bytes", []byte{1, 2, 3}, "cannot scan bytes into UUID"},
+		{"invalid-type", 12345, "cannot scan type int into UUID"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var scanned uuid.UUID
+			err := scanned.Scan(test.src)
+			if test.err == "" {
+				verify.NoError(t, err)
+				verify.Equal(t, scanned, u)
+			} else {
+				verify.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+// TestValue tests the conversion of UUIDs into database values.
+func TestValue(t *testing.T) {
+	u := uuid.New()
+
+	var valuer driver.Valuer = u
+	v, err := valuer.Value()
+	verify.NoError(t, err)
+	verify.Equal(t, v.(string), u.String())
+
+	var scanner sql.Scanner = &u
+	verify.NoError(t, scanner.Scan(v))
+}
+
+// TestNullUUID tests scanning and storing of nullable UUIDs.
+func TestNullUUID(t *testing.T) {
+	u := uuid.New()
+
+	var nu uuid.NullUUID
+	err := nu.Scan(u.String())
+	verify.NoError(t, err)
+	verify.True(t, nu.Valid)
+	verify.Equal(t, nu.UUID, u)
+
+	v, err := nu.Value()
+	verify.NoError(t, err)
+	verify.Equal(t, v.(string), u.String())
+
+	err = nu.Scan(nil)
+	verify.NoError(t, err)
+	verify.False(t, nu.Valid)
+	verify.Equal(t, nu.UUID, uuid.UUID{})
+
+	v, err = nu.Value()
+	verify.NoError(t, err)
+	verify.Nil(t, v)
+
+	err = nu.Scan("no-uuid")
+	verify.Error(t, err)
+	verify.False(t, nu.Valid)
+}
+
+// EOF
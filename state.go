@@ -0,0 +1,198 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+//--------------------
+// STATE STORE
+//--------------------
+
+// State contains the state of a Generator needed to keep time-based
+// UUIDs unique across restarts and clock rollbacks as recommended by
+// RFC 9562 Section 6.3. The timestamps are no real last values but
+// reserved upper bounds, the generator never creates UUIDs beyond
+// them without storing a new state before.
+type State struct {
+	Timestamp     uint64 `json:"timestamp"`      // Gregorian timestamp bound of v1 and v6 in 100ns
+	ClockSequence uint16 `json:"clock_sequence"` // Last clock sequence of v1 and v6
+	UnixMilli     int64  `json:"unix_milli"`     // Unix millisecond bound of v7
+}
+
+// StateStore persists the state of a Generator in a stable storage.
+// It is set with the option WithStateStore.
+type StateStore interface {
+	// Load returns the stored state. If there is none yet the zero
+	// state has to be returned.
+	Load() (State, error)
+
+	// Save stores the state.
+	Save(state State) error
+}
+
+// FileStateStore is a StateStore keeping the state as JSON in a file.
+type FileStateStore struct {
+	path string
+}
+
+// NewFileStateStore creates a store for the state in the file with the
+// given path. The file is created with the first save.
+func NewFileStateStore(path string) *FileStateStore {
+	return &FileStateStore{
+		path: path,
+	}
+}
+
+// Load implements StateStore.
+func (s *FileStateStore) Load() (State, error) {
+	var state State
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return state, nil
+		}
+		return state, fmt.Errorf("cannot read state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("cannot unmarshal state: %w", err)
+	}
+	return state, nil
+}
+
+// Save implements StateStore. The file is replaced atomically, so an
+// interrupted save leaves the former state intact.
+func (s *FileStateStore) Save(state State) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("cannot marshal state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("cannot create state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot write state: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("cannot sync state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("cannot close state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("cannot replace state file: %w", err)
+	}
+	return nil
+}
+
+//--------------------
+// GENERATOR PERSISTENCE
+//--------------------
+
+// persistence manages loading and checkpointing the state of a Generator.
+type persistence struct {
+	store    StateStore
+	interval time.Duration
+	loadOnce sync.Once
+	loadErr  error
+	mu       sync.Mutex
+	state    State
+}
+
+// loadState loads the state once before the first time-based UUID
+// is generated. A failed load is returned by every following call.
+func (g *Generator) loadState() error {
+	if g.persistence == nil {
+		return nil
+	}
+	p := g.persistence
+	p.loadOnce.Do(func() {
+		state, err := p.store.Load()
+		if err != nil {
+			p.loadErr = fmt.Errorf("cannot load generator state: %w", err)
+			return
+		}
+		p.state = state
+
+		// Continue behind the reserved bounds. If the clock is below
+		// them the generation behaves like after a clock rollback.
+		g.v6.mu.Lock()
+		g.v6.lastTime = state.Timestamp
+		g.v6.lastClockSeq = state.ClockSequence
+		g.v6.reserved = state.Timestamp
+		g.v6.mu.Unlock()
+
+		g.v7.mu.Lock()
+		g.v7.lastMs = state.UnixMilli
+		g.v7.reservedMs = state.UnixMilli
+		g.v7.restoredMs = state.UnixMilli
+		g.v7.mu.Unlock()
+	})
+	return p.loadErr
+}
+
+// checkpointV6 stores a new reserved timestamp if the last one has been
+// reached. It has to be called with the v6 state locked.
+func (g *Generator) checkpointV6() error {
+	if g.persistence == nil || g.v6.lastTime < g.v6.reserved {
+		return nil
+	}
+	p := g.persistence
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	reserved := g.v6.lastTime + uint64(p.interval/100)
+	state := p.state
+	state.Timestamp = reserved
+	state.ClockSequence = g.v6.lastClockSeq
+	if err := p.store.Save(state); err != nil {
+		return fmt.Errorf("cannot save generator state: %w", err)
+	}
+	p.state = state
+	g.v6.reserved = reserved
+	return nil
+}
+
+// checkpointV7 stores a new reserved millisecond if the last one has been
+// reached. It has to be called with the v7 state locked.
+func (g *Generator) checkpointV7() error {
+	if g.persistence == nil || g.v7.lastMs < g.v7.reservedMs {
+		return nil
+	}
+	p := g.persistence
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	reservedMs := g.v7.lastMs + max(p.interval.Milliseconds(), 1)
+	state := p.state
+	state.UnixMilli = reservedMs
+	if err := p.store.Save(state); err != nil {
+		return fmt.Errorf("cannot save generator state: %w", err)
+	}
+	p.state = state
+	g.v7.reservedMs = reservedMs
+	return nil
+}
+
+// EOF
@@ -0,0 +1,203 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestFileStateStore tests loading and saving the state in a file.
+func TestFileStateStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uuid.state")
+	store := uuid.NewFileStateStore(path)
+
+	// Missing file returns zero state
+	state, err := store.Load()
+	verify.NoError(t, err)
+	verify.Equal(t, state, uuid.State{})
+
+	// Round-trip
+	saved := uuid.State{
+		Timestamp:     0x1f0123456789abc,
+		ClockSequence: 0x1234,
+		UnixMilli:     1766599200000,
+	}
+	err = store.Save(saved)
+	verify.NoError(t, err)
+	state, err = store.Load()
+	verify.NoError(t, err)
+	verify.Equal(t, state, saved)
+
+	// Invalid content
+	err = os.WriteFile(path, []byte("no json"), 0o600)
+	verify.NoError(t, err)
+	_, err = store.Load()
+	verify.ErrorContains(t, err, "cannot unmarshal state")
+}
+
+// TestGeneratorRestart tests that a generator continues behind the
+// UUIDs created before a restart, even if the clock has been set back.
+func TestGeneratorRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "uuid.state")
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	gen := uuid.NewGenerator(
+		uuid.WithClock(clock),
+		uuid.WithStateStore(uuid.NewFileStateStore(path), time.Second),
+	)
+	var lastV6, lastV7 uuid.UUID
+	for range 100 {
+		u, err := gen.NewV6()
+		verify.NoError(t, err)
+		lastV6 = u
+		u, err = gen.NewV7()
+		verify.NoError(t, err)
+		lastV7 = u
+		now = now.Add(5 * time.Millisecond)
+	}
+
+	// Restart with clock set back by one minute
+	now = now.Add(-time.Minute)
+	gen = uuid.NewGenerator(
+		uuid.WithClock(clock),
+		uuid.WithStateStore(uuid.NewFileStateStore(path), time.Second),
+	)
+	for range 100 {
+		u, err := gen.NewV6()
+		verify.NoError(t, err)
+		verify.True(t, lastV6.Less(u), "v6 after restart is greater")
+		lastV6 = u
+		u, err = gen.NewV7()
+		verify.NoError(t, err)
+		verify.True(t, lastV7.Less(u), "v7 after restart is greater")
+		lastV7 = u
+	}
+
+	// Without the store the UUIDs would be smaller
+	gen = uuid.NewGenerator(uuid.WithClock(clock))
+	u, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.True(t, u.Less(lastV7), "v7 without store is smaller")
+}
+
+// TestGeneratorRestartDriftLimit tests that the reserved bound loaded
+// after a restart does not count as drift.
+func TestGeneratorRestartDriftLimit(t *testing.T) {
+	store := &memoryStateStore{}
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	newGenerator := func() *uuid.Generator {
+		return uuid.NewGenerator(
+			uuid.WithClock(clock),
+			uuid.WithStateStore(store, time.Second),
+			uuid.WithV7DriftLimit(10*time.Millisecond),
+		)
+	}
+
+	gen := newGenerator()
+	lastV7, err := gen.NewV7()
+	verify.NoError(t, err)
+
+	// Restart a bit later continues behind the reserved bound
+	now = now.Add(2 * time.Millisecond)
+	gen = newGenerator()
+	restored := time.UnixMilli(store.state.UnixMilli)
+	verify.True(t, restored.Sub(now) > 10*time.Millisecond, "reserved bound ahead more than the limit")
+	for range 100 {
+		u, err := gen.NewV7()
+		verify.NoError(t, err)
+		verify.True(t, lastV7.Less(u), "v7 after restart is greater")
+		lastV7 = u
+	}
+
+	// Drift beyond the reserved bound is still limited
+	for err == nil {
+		var u uuid.UUID
+		if u, err = gen.NewV7(); err == nil {
+			lastV7 = u
+		}
+	}
+	verify.True(t, errors.Is(err, uuid.ErrClockDriftExceeded))
+	ts, err := lastV7.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(restored.Add(10*time.Millisecond)), "last v7 at limit behind the reserved bound")
+}
+
+// TestGeneratorCheckpoints tests that the state is only saved when the
+// reserved interval is reached.
+func TestGeneratorCheckpoints(t *testing.T) {
+	store := &memoryStateStore{}
+	gen := uuid.NewGenerator(
+		uuid.WithClock(steppingClock(time.Now(), time.Millisecond)),
+		uuid.WithStateStore(store, 100*time.Millisecond),
+	)
+
+	// 1000 UUIDs within one second need 10 checkpoints
+	for range 1000 {
+		_, err := gen.NewV7()
+		verify.NoError(t, err)
+	}
+	verify.Equal(t, store.loads, 1)
+	verify.Equal(t, store.saves, 10)
+}
+
+// TestGeneratorStateErrors tests failing state stores.
+func TestGeneratorStateErrors(t *testing.T) {
+	store := &memoryStateStore{loadErr: errors.New("load failed")}
+	gen := uuid.NewGenerator(uuid.WithStateStore(store, time.Second))
+	_, err := gen.NewV6()
+	verify.ErrorContains(t, err, "cannot load generator state: load failed")
+	_, err = gen.NewV7()
+	verify.ErrorContains(t, err, "cannot load generator state: load failed")
+	verify.Equal(t, store.loads, 1)
+
+	store = &memoryStateStore{saveErr: errors.New("save failed")}
+	gen = uuid.NewGenerator(uuid.WithStateStore(store, time.Second))
+	_, err = gen.NewV6()
+	verify.ErrorContains(t, err, "cannot save generator state: save failed")
+	_, err = gen.NewV7()
+	verify.ErrorContains(t, err, "cannot save generator state: save failed")
+}
+
+// Helpers
+
+// memoryStateStore keeps the state in memory and counts the accesses.
+type memoryStateStore struct {
+	state   uuid.State
+	loads   int
+	saves   int
+	loadErr error
+	saveErr error
+}
+
+func (s *memoryStateStore) Load() (uuid.State, error) {
+	s.loads++
+	return s.state, s.loadErr
+}
+
+func (s *memoryStateStore) Save(state uuid.State) error {
+	if s.saveErr != nil {
+		return s.saveErr
+	}
+	s.saves++
+	s.state = state
+	return nil
+}
+
+// EOF
@@ -0,0 +1,191 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+//--------------------
+// ERRORS
+//--------------------
+
+// ErrInvalidPrefix is returned when the type prefix of a TypedID is not
+// valid. See TypedID for the syntax.
+var ErrInvalidPrefix = errors.New("invalid type prefix")
+
+// ErrPrefixMismatch is returned when a parsed TypedID has another type
+// prefix than expected.
+var ErrPrefixMismatch = errors.New("type prefix mismatch")
+
+//--------------------
+// TYPED ID
+//--------------------
+
+// maxPrefixLength is the maximum length of a type prefix.
+const maxPrefixLength = 63
+
+// Prefixer provides the type prefix of a TypedID. It is implemented by
+// marker types, the prefix is taken from their zero value, e.g.
+//
+//	type UserPrefix struct{}
+//
+//	func (UserPrefix) Prefix() string { return "user" }
+type Prefixer interface {
+	Prefix() string
+}
+
+// TypedID is an identifier combining a type prefix with a UUID in the
+// style of TypeID, e.g. "user_01h455vb4pex5vsknk084sn02q". The prefix
+// is defined by the type parameter, so TypedID[UserPrefix] and
+// TypedID[OrderPrefix] are distinct types and parsing always checks
+// the prefix. It consists of up to 63 lower case letters and underscores,
+// but must neither start nor end with an underscore. It is separated by
+// an underscore from the suffix, the UUID in lower case Crockford Base32.
+// An empty prefix results in the suffix only.
+type TypedID[P Prefixer] struct {
+	uuid UUID
+}
+
+// NewTypedID creates a TypedID with a new Version 7 UUID of the default
+// generator, so the IDs sort by time.
+func NewTypedID[P Prefixer]() (TypedID[P], error) {
+	uuid, err := NewV7()
+	if err != nil {
+		return TypedID[P]{}, err
+	}
+	return NewTypedIDFrom[P](uuid)
+}
+
+// NewTypedIDFrom creates a TypedID with the given UUID, e.g. one of an
+// own generator.
+func NewTypedIDFrom[P Prefixer](uuid UUID) (TypedID[P], error) {
+	if err := validatePrefix(prefixOf[P]()); err != nil {
+		return TypedID[P]{}, err
+	}
+	return TypedID[P]{uuid: uuid}, nil
+}
+
+// ParseTypedID parses a TypedID and checks that it has the prefix of
+// the type. Otherwise an error wrapping ErrPrefixMismatch is returned,
+// so that IDs of different types cannot be mixed up.
+func ParseTypedID[P Prefixer](source string) (TypedID[P], error) {
+	expected := prefixOf[P]()
+	if err := validatePrefix(expected); err != nil {
+		return TypedID[P]{}, err
+	}
+	prefix, suffix := "", source
+	if i := strings.LastIndexByte(source, '_'); i >= 0 {
+		prefix, suffix = source[:i], source[i+1:]
+		if prefix == "" {
+			return TypedID[P]{}, fmt.Errorf("%w: empty prefix with separator in %q", ErrInvalidPrefix, source)
+		}
+	}
+	if prefix != expected {
+		if err := validatePrefix(prefix); err != nil {
+			return TypedID[P]{}, err
+		}
+		return TypedID[P]{}, fmt.Errorf("%w: expected %q, got %q", ErrPrefixMismatch, expected, prefix)
+	}
+	uuid, err := decodeBase(suffix, "TypedID suffix", &typedIDDigits, 32, base32Length)
+	if err != nil {
+		return TypedID[P]{}, err
+	}
+	return TypedID[P]{uuid: uuid}, nil
+}
+
+// Prefix returns the type prefix of the TypedID.
+func (id TypedID[P]) Prefix() string {
+	return prefixOf[P]()
+}
+
+// UUID returns the UUID of the TypedID.
+func (id TypedID[P]) UUID() UUID {
+	return id.uuid
+}
+
+// String returns the prefix and the lower case Base32 suffix.
+func (id TypedID[P]) String() string {
+	suffix := strings.ToLower(id.uuid.EncodeBase32())
+	if prefix := prefixOf[P](); prefix != "" {
+		return prefix + "_" + suffix
+	}
+	return suffix
+}
+
+// MarshalText implements encoding.TextMarshaler. An invalid prefix of
+// the type returns an error wrapping ErrInvalidPrefix, as the text could
+// not be parsed again.
+func (id TypedID[P]) MarshalText() ([]byte, error) {
+	if err := validatePrefix(prefixOf[P]()); err != nil {
+		return nil, err
+	}
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text must
+// have the prefix of the type.
+func (id *TypedID[P]) UnmarshalText(text []byte) error {
+	parsed, err := ParseTypedID[P](string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+//--------------------
+// PRIVATE HELPERS
+//--------------------
+
+// typedIDDigits maps the lower case Base32 characters of the suffix
+// to their digit values. Other than ParseBase32 no aliases are allowed.
+var typedIDDigits = newTypedIDDigits()
+
+// newTypedIDDigits creates the table of the suffix digits.
+func newTypedIDDigits() [256]byte {
+	digits := [256]byte{}
+	for i := range digits {
+		digits[i] = invalidDigit
+	}
+	alphabet := strings.ToLower(base32Alphabet)
+	for i := 0; i < len(alphabet); i++ {
+		digits[alphabet[i]] = byte(i)
+	}
+	return digits
+}
+
+// prefixOf returns the type prefix of a Prefixer type.
+func prefixOf[P Prefixer]() string {
+	var p P
+	return p.Prefix()
+}
+
+// validatePrefix checks the syntax of a type prefix.
+func validatePrefix(prefix string) error {
+	if len(prefix) > maxPrefixLength {
+		return fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidPrefix, prefix, maxPrefixLength)
+	}
+	if strings.HasPrefix(prefix, "_") || strings.HasSuffix(prefix, "_") {
+		return fmt.Errorf("%w: %q starts or ends with an underscore", ErrInvalidPrefix, prefix)
+	}
+	for i := 0; i < len(prefix); i++ {
+		if c := prefix[i]; (c < 'a' || c > 'z') && c != '_' {
+			return fmt.Errorf("%w: %q contains invalid character %q", ErrInvalidPrefix, prefix, c)
+		}
+	}
+	return nil
+}
+
+// EOF
@@ -0,0 +1,140 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestTypedID tests creating, formatting, and parsing typed IDs.
+func TestTypedID(t *testing.T) {
+	u, err := uuid.Parse("01890a5d-ac96-774b-bcce-b302099a8057")
+	verify.NoError(t, err)
+	id, err := uuid.NewTypedIDFrom[userPrefix](u)
+	verify.NoError(t, err)
+	verify.Equal(t, id.String(), "user_01h455vb4pex5vsknk084sn02q")
+	verify.Equal(t, id.Prefix(), "user")
+	verify.Equal(t, id.UUID(), u)
+
+	parsed, err := uuid.ParseTypedID[userPrefix]("user_01h455vb4pex5vsknk084sn02q")
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, id)
+
+	// Prefixes may contain underscores, the suffix follows the last one.
+	accountID, err := uuid.ParseTypedID[accountPrefix]("billing_account_01h455vb4pex5vsknk084sn02q")
+	verify.NoError(t, err)
+	verify.Equal(t, accountID.Prefix(), "billing_account")
+	verify.Equal(t, accountID.UUID(), u)
+
+	// Without prefix only the suffix is used.
+	plainID, err := uuid.NewTypedIDFrom[noPrefix](u)
+	verify.NoError(t, err)
+	verify.Equal(t, plainID.String(), "01h455vb4pex5vsknk084sn02q")
+	plainParsed, err := uuid.ParseTypedID[noPrefix]("01h455vb4pex5vsknk084sn02q")
+	verify.NoError(t, err)
+	verify.Equal(t, plainParsed, plainID)
+
+	// New typed IDs are based on v7 and sort by time.
+	idA, err := uuid.NewTypedID[orderPrefix]()
+	verify.NoError(t, err)
+	idB, err := uuid.NewTypedID[orderPrefix]()
+	verify.NoError(t, err)
+	verify.Equal(t, idA.UUID().Version(), uuid.V7)
+	verify.True(t, idA.String() < idB.String(), "typed IDs must sort by time")
+}
+
+// TestTypedIDPrefixMismatch tests that IDs of other types are rejected.
+func TestTypedIDPrefixMismatch(t *testing.T) {
+	id, err := uuid.NewTypedID[userPrefix]()
+	verify.NoError(t, err)
+
+	parsed, err := uuid.ParseTypedID[userPrefix](id.String())
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, id)
+	_, err = uuid.ParseTypedID[orderPrefix](id.String())
+	verify.True(t, errors.Is(err, uuid.ErrPrefixMismatch))
+	_, err = uuid.ParseTypedID[noPrefix](id.String())
+	verify.True(t, errors.Is(err, uuid.ErrPrefixMismatch))
+	_, err = uuid.ParseTypedID[userPrefix](strings.TrimPrefix(id.String(), "user_"))
+	verify.True(t, errors.Is(err, uuid.ErrPrefixMismatch))
+
+	// JSON always checks the prefix of the type, also for zero values.
+	data, err := json.Marshal(id)
+	verify.NoError(t, err)
+	var userID uuid.TypedID[userPrefix]
+	verify.NoError(t, json.Unmarshal(data, &userID))
+	verify.Equal(t, userID, id)
+	var orderID uuid.TypedID[orderPrefix]
+	err = json.Unmarshal(data, &orderID)
+	verify.True(t, errors.Is(err, uuid.ErrPrefixMismatch))
+}
+
+// TestTypedIDInvalid tests the rejection of invalid typed IDs.
+func TestTypedIDInvalid(t *testing.T) {
+	_, err := uuid.NewTypedID[upperPrefix]()
+	verify.True(t, errors.Is(err, uuid.ErrInvalidPrefix))
+	_, err = uuid.ParseTypedID[upperPrefix]("User_01h455vb4pex5vsknk084sn02q")
+	verify.True(t, errors.Is(err, uuid.ErrInvalidPrefix))
+	_, err = uuid.NewTypedID[underscorePrefix]()
+	verify.True(t, errors.Is(err, uuid.ErrInvalidPrefix))
+	_, err = uuid.TypedID[upperPrefix]{}.MarshalText()
+	verify.True(t, errors.Is(err, uuid.ErrInvalidPrefix))
+	_, err = json.Marshal(uuid.TypedID[underscorePrefix]{})
+	verify.True(t, errors.Is(err, uuid.ErrInvalidPrefix))
+	_, err = uuid.NewTypedID[longPrefix]()
+	verify.NoError(t, err)
+
+	sources := []string{
+		"_01h455vb4pex5vsknk084sn02q",
+		"User_01h455vb4pex5vsknk084sn02q",
+		"user1_01h455vb4pex5vsknk084sn02q",
+		"us-er_01h455vb4pex5vsknk084sn02q",
+		"user_01H455VB4PEX5VSKNK084SN02Q",
+		"user_01h455vb4pex5vsknk084sn02",
+		"user_81h455vb4pex5vsknk084sn02q",
+		"user_01h455vb4pex5vsknk084sn02u",
+		"user_01h455vb4pex5vsknk084snO2q",
+		"user",
+	}
+	for _, source := range sources {
+		_, err := uuid.ParseTypedID[userPrefix](source)
+		verify.True(t, err != nil, source)
+	}
+}
+
+// Helpers
+
+// Prefix types for typed IDs.
+type (
+	userPrefix       struct{}
+	orderPrefix      struct{}
+	accountPrefix    struct{}
+	noPrefix         struct{}
+	upperPrefix      struct{}
+	underscorePrefix struct{}
+	longPrefix       struct{}
+)
+
+func (userPrefix) Prefix() string       { return "user" }
+func (orderPrefix) Prefix() string      { return "order" }
+func (accountPrefix) Prefix() string    { return "billing_account" }
+func (noPrefix) Prefix() string         { return "" }
+func (upperPrefix) Prefix() string      { return "User" }
+func (underscorePrefix) Prefix() string { return "user_" }
+func (longPrefix) Prefix() string       { return strings.Repeat("a", 63) }
+
+// EOF
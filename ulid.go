@@ -0,0 +1,55 @@
+// Tideland Go UUID
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid
+
+//--------------------
+// IMPORTS
+//--------------------
+
+import (
+	"fmt"
+)
+
+//--------------------
+// ULID
+//--------------------
+
+// ULID returns the 26 character ULID representation of a Version 7
+// UUID. Both share the 48-bit Unix timestamp in milliseconds and the
+// Crockford Base32 encoding of the ULID preserves the order. Other
+// versions return an error.
+func (uuid UUID) ULID() (string, error) {
+	if uuid.Version() != V7 {
+		return "", fmt.Errorf("%w: cannot convert version %d to ULID", ErrInvalidVersion, uuid.Version())
+	}
+	return uuid.EncodeBase32(), nil
+}
+
+// ParseULID parses a ULID into a UUID. All 128 bits are taken over, so
+// the result can be converted back into the same ULID, but in general
+// it is no valid Version 7 UUID. See ParseULIDAsV7.
+func ParseULID(source string) (UUID, error) {
+	return decodeBase(source, "ULID", &base32Digits, 32, base32Length)
+}
+
+// ParseULIDAsV7 parses a ULID into a Version 7 UUID. The timestamp is
+// kept while version and variant overwrite 6 of the 80 random bits.
+// So the UUID can be joined with natively generated Version 7 UUIDs,
+// but its ULID representation differs from the source in those bits.
+func ParseULIDAsV7(source string) (UUID, error) {
+	uuid, err := ParseULID(source)
+	if err != nil {
+		return uuid, err
+	}
+
+	uuid.setVersion(V7)
+	uuid.setVariant()
+	return uuid, nil
+}
+
+// EOF
@@ -0,0 +1,70 @@
+// Tideland Go UUID - Unit Tests
+//
+// Copyright (C) 2021-2025 Frank Mueller / Tideland / Germany
+//
+// All rights reserved. Use of this source code is governed
+// by the new BSD license.
+
+package uuid_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"tideland.dev/go/asserts/verify"
+
+	"tideland.dev/go/uuid"
+)
+
+// Tests
+
+// TestULID tests the conversion of v7 UUIDs into ULIDs and back.
+func TestULID(t *testing.T) {
+	v7, err := uuid.Parse("017F22E2-79B0-7CC3-98C4-DC0C0C07398F")
+	verify.NoError(t, err)
+	ulid, err := v7.ULID()
+	verify.NoError(t, err)
+	verify.Equal(t, ulid, "01FWHE4YDGFK1SHH6W1G60EECF")
+
+	parsed, err := uuid.ParseULID(ulid)
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, v7)
+	parsed, err = uuid.ParseULIDAsV7(ulid)
+	verify.NoError(t, err)
+	verify.Equal(t, parsed, v7)
+
+	v4, err := uuid.NewV4()
+	verify.NoError(t, err)
+	_, err = v4.ULID()
+	verify.True(t, errors.Is(err, uuid.ErrInvalidVersion))
+}
+
+// TestParseULID tests parsing ULIDs created by other systems.
+func TestParseULID(t *testing.T) {
+	// Example of the ULID specification with 2016-07-30 23:54:10.259 UTC.
+	ulid := "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+	want := time.UnixMilli(1469922850259)
+
+	parsed, err := uuid.ParseULID(ulid)
+	verify.NoError(t, err)
+	verify.Equal(t, parsed.EncodeBase32(), ulid)
+
+	parsed, err = uuid.ParseULIDAsV7(ulid)
+	verify.NoError(t, err)
+	verify.Equal(t, parsed.Version(), uuid.V7)
+	verify.Equal(t, parsed.Variant(), uuid.VariantRFC4122)
+	ts, err := parsed.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(want), "timestamp of the ULID must be kept")
+
+	// Lower case and overflowing ULIDs.
+	_, err = uuid.ParseULID("01arz3ndektsv4rrffq69g5fav")
+	verify.NoError(t, err)
+	_, err = uuid.ParseULID("81ARZ3NDEKTSV4RRFFQ69G5FAV")
+	verify.True(t, errors.Is(err, uuid.ErrInvalidFormat))
+	_, err = uuid.ParseULIDAsV7("01ARZ3NDEKTSV4RRFFQ69G5FA")
+	verify.True(t, errors.Is(err, uuid.ErrInvalidFormat))
+}
+
+// EOF
@@ -12,16 +12,16 @@ package uuid
 //--------------------
 
 import (
+	"bytes"
 	"crypto/md5"
-	"crypto/rand"
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/binary"
-	"encoding/hex"
+	"errors"
 	"fmt"
-	"net"
-	"os"
-	"strings"
-	"sync"
+	"hash"
+	"slices"
 	"time"
 )
 
@@ -41,6 +41,13 @@ const (
 	V5 Version = 5
 	V6 Version = 6
 	V7 Version = 7
+	V8 Version = 8
+)
+
+// Versions reported for the special Nil and Max UUIDs.
+const (
+	VersionNil Version = 0x0
+	VersionMax Version = 0xf
 )
 
 // Variant represents a UUID's variant.
@@ -67,10 +74,53 @@ const (
 	Org    Domain = 2 // Organization domain
 )
 
+// Errors returned when parsing or validating UUIDs. They are wrapped
+// with more details and can be checked with errors.Is.
+var (
+	ErrInvalidFormat  = errors.New("invalid UUID format")
+	ErrInvalidVersion = errors.New("invalid UUID version")
+	ErrInvalidVariant = errors.New("invalid UUID variant")
+)
+
+// ErrTimeOutOfRange is returned when a time cannot be stored in the
+// timestamp of a time-based UUID. Version 1 and 6 UUIDs support times
+// from 1582-10-15 for about 3653 years, Version 7 UUIDs times from
+// 1970-01-01 for about 8919 years.
+var ErrTimeOutOfRange = errors.New("time out of range")
+
+// gregorianEpoch is the offset between the Gregorian epoch (1582-10-15)
+// used by v1, v2, and v6 and the Unix epoch in 100ns intervals.
+const gregorianEpoch = 0x01b21dd213814000
+
+// Largest timestamps of the time-based UUIDs.
+const (
+	maxGregorianTimestamp = 1<<60 - 1
+	maxUnixMilli          = 1<<48 - 1
+)
+
 // UUID represents a universal identifier with 16 bytes.
 // See http://en.wikipedia.org/wiki/Universally_unique_identifier.
 type UUID [16]byte
 
+// Special UUIDs defined by RFC 9562. The Nil UUID has all bits set to
+// zero and can be used to mark unset values, the Max UUID has all bits
+// set to one and can be used as upper bound of ranges. They are only
+// conveniences, the package itself uses own copies, so changing them
+// does not affect IsNil, IsMax, or the range bounds.
+var (
+	Nil = nilUUID
+	Max = maxUUID
+)
+
+// nilUUID and maxUUID are the internal copies of Nil and Max.
+var (
+	nilUUID = UUID{}
+	maxUUID = UUID{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+	}
+)
+
 // New returns a new UUID with based on the default version 4.
 func New() UUID {
 	uuid, err := NewV4()
@@ -82,32 +132,12 @@ func New() UUID {
 }
 
 // NewV1 generates a new UUID based on version 1 (MAC address and
-// date-time).
+// date-time). The fields are stored in network byte order as defined
+// by RFC 9562. Like NewV6 it ensures monotonicity with a clock sequence
+// incremented for UUIDs generated within the same timestamp period,
+// both versions share this state.
 func NewV1() (UUID, error) {
-	uuid := UUID{}
-	epoch := int64(0x01b21dd213814000)
-	now := uint64(time.Now().UnixNano()/100 + epoch)
-
-	clockSeqRand := [2]byte{}
-	if _, err := rand.Read(clockSeqRand[:]); err != nil {
-		return uuid, err
-	}
-	clockSeq := binary.LittleEndian.Uint16(clockSeqRand[:])
-
-	timeLow := uint32(now & (0x100000000 - 1))
-	timeMid := uint16((now >> 32) & 0xffff)
-	timeHighVer := uint16((now >> 48) & 0x0fff)
-	clockSeq &= 0x3fff
-
-	binary.LittleEndian.PutUint32(uuid[0:4], timeLow)
-	binary.LittleEndian.PutUint16(uuid[4:6], timeMid)
-	binary.LittleEndian.PutUint16(uuid[6:8], timeHighVer)
-	binary.LittleEndian.PutUint16(uuid[8:10], clockSeq)
-	copy(uuid[10:16], cachedMACAddress)
-
-	uuid.setVersion(V1)
-	uuid.setVariant()
-	return uuid, nil
+	return DefaultGenerator().NewV1()
 }
 
 // NewV2 generates a new UUID based on version 2 (DCE Security).
@@ -116,87 +146,43 @@ func NewV1() (UUID, error) {
 // domain and the user's GID for the Group domain. The meaning of id for
 // the Org domain or on non-POSIX systems is site-defined.
 //
-// For a given domain/id pair, the same token may be returned for up to
-// 7 minutes and 10 seconds.
+// The lower 32 bits of the timestamp are replaced by the id and the
+// clock sequence is reduced to 6 bits. So for a given domain/id pair
+// only 64 UUIDs can be generated within 7 minutes and 10 seconds.
+// Beyond that an error wrapping ErrV2Exhausted is returned. The used
+// clock sequences are tracked per domain/id pair for this period, so
+// at most 65536 different pairs can be used within it.
 func NewV2(domain Domain, id uint32) (UUID, error) {
-	// Start with a v1 UUID
-	uuid, err := NewV1()
-	if err != nil {
-		return uuid, err
-	}
-
-	// Replace time_low with the local ID
-	binary.BigEndian.PutUint32(uuid[0:4], id)
-
-	// Set the domain in the clock_seq_low position
-	uuid[9] = byte(domain)
-
-	// Set version to 2
-	uuid.setVersion(V2)
-
-	return uuid, nil
+	return DefaultGenerator().NewV2(domain, id)
 }
 
 // NewV2Person returns a DCE Security (Version 2) UUID in the Person
 // domain with the id returned by os.Getuid.
 func NewV2Person() (UUID, error) {
-	return NewV2(Person, uint32(os.Getuid()))
+	return DefaultGenerator().NewV2Person()
 }
 
 // NewV2Group returns a DCE Security (Version 2) UUID in the Group
 // domain with the id returned by os.Getgid.
 func NewV2Group() (UUID, error) {
-	return NewV2(Group, uint32(os.Getgid()))
+	return DefaultGenerator().NewV2Group()
 }
 
 // NewV3 generates a new UUID based on version 3 (MD5 hash of a namespace
 // and a name).
 func NewV3(ns UUID, name []byte) (UUID, error) {
-	uuid := UUID{}
-	hash := md5.New()
-	if _, err := hash.Write(ns.dump()); err != nil {
-		return uuid, err
-	}
-	if _, err := hash.Write(name); err != nil {
-		return uuid, err
-	}
-	copy(uuid[:], hash.Sum([]byte{})[:16])
-
-	uuid.setVersion(V3)
-	uuid.setVariant()
-	return uuid, nil
+	return newNameBased(md5.New(), V3, ns, name)
 }
 
 // NewV4 generates a new UUID based on version 4 (strong random number).
 func NewV4() (UUID, error) {
-	uuidRand := make([]byte, 16)
-	_, err := rand.Read(uuidRand)
-	if err != nil {
-		return UUID(uuidRand), err
-	}
-
-	uuid := UUID(uuidRand)
-	uuid.setVersion(V4)
-	uuid.setVariant()
-	return uuid, nil
+	return DefaultGenerator().NewV4()
 }
 
 // NewV5 generates a new UUID based on version 5 (SHA1 hash of a namespace
 // and a name).
 func NewV5(ns UUID, name []byte) (UUID, error) {
-	uuid := UUID{}
-	hash := sha1.New()
-	if _, err := hash.Write(ns.dump()); err != nil {
-		return uuid, err
-	}
-	if _, err := hash.Write(name); err != nil {
-		return uuid, err
-	}
-	copy(uuid[:], hash.Sum([]byte{})[:16])
-
-	uuid.setVersion(V5)
-	uuid.setVariant()
-	return uuid, nil
+	return newNameBased(sha1.New(), V5, ns, name)
 }
 
 // NewV6 generates a new UUID based on version 6 (reordered Gregorian timestamp).
@@ -206,31 +192,7 @@ func NewV5(ns UUID, name []byte) (UUID, error) {
 // This implementation ensures monotonicity by using a counter for the clock sequence
 // when UUIDs are generated within the same timestamp period.
 func NewV6() (UUID, error) {
-	uuid := UUID{}
-	epoch := int64(0x01b21dd213814000)
-	now := uint64(time.Now().UnixNano()/100 + epoch)
-
-	// Get monotonic clock sequence and adjusted timestamp
-	adjustedNow, clockSeq, err := getV6ClockSeq(now)
-	if err != nil {
-		return uuid, err
-	}
-
-	// Extract timestamp components
-	timeHigh := uint32((adjustedNow >> 28) & 0xffffffff) // Most significant 32 bits
-	timeMid := uint16((adjustedNow >> 12) & 0xffff)      // Middle 16 bits
-	timeLow := uint16(adjustedNow & 0x0fff)              // Least significant 12 bits
-
-	// Store in big-endian order for v6
-	binary.BigEndian.PutUint32(uuid[0:4], timeHigh)
-	binary.BigEndian.PutUint16(uuid[4:6], timeMid)
-	binary.BigEndian.PutUint16(uuid[6:8], timeLow)
-	binary.BigEndian.PutUint16(uuid[8:10], clockSeq)
-	copy(uuid[10:16], cachedMACAddress)
-
-	uuid.setVersion(V6)
-	uuid.setVariant()
-	return uuid, nil
+	return DefaultGenerator().NewV6()
 }
 
 // NewV7 generates a new UUID based on version 7 (Unix Epoch timestamp).
@@ -240,44 +202,138 @@ func NewV6() (UUID, error) {
 // This implementation ensures monotonicity by using a counter for UUIDs
 // generated within the same millisecond, as recommended by RFC 9562 Section 6.2.
 func NewV7() (UUID, error) {
+	return DefaultGenerator().NewV7()
+}
+
+// NewV1At generates a new UUID based on version 1 for the given time
+// instead of the current one, e.g. for backfilling historical records.
+// The monotonic state used by NewV1 is neither used nor changed. Instead
+// the clock sequence is random. So only its 14 bits distinguish UUIDs
+// created for the same 100ns interval. Times out of the range of v1
+// return ErrTimeOutOfRange.
+func NewV1At(t time.Time) (UUID, error) {
+	return DefaultGenerator().NewV1At(t)
+}
+
+// NewV6At generates a new UUID based on version 6 for the given time
+// instead of the current one, e.g. for backfilling historical records.
+//
+// The monotonic state used by NewV6 is neither used nor changed. Instead
+// the clock sequence is random. So only its 14 bits distinguish UUIDs
+// created for the same 100ns interval, and they are not ordered among
+// each other. Prefer NewV7At for bulk imports with coarse timestamps.
+// Times out of the range of v6 return ErrTimeOutOfRange.
+func NewV6At(t time.Time) (UUID, error) {
+	return DefaultGenerator().NewV6At(t)
+}
+
+// NewV7At generates a new UUID based on version 7 for the given time
+// instead of the current one, e.g. for backfilling historical records.
+//
+// The monotonic state used by NewV7 is neither used nor changed. Instead
+// all 74 bits after the timestamp are random. So UUIDs created for the
+// same millisecond are unique with a high probability, but not ordered
+// among each other. Times before 1970 or after the 48-bit range of v7
+// return ErrTimeOutOfRange.
+func NewV7At(t time.Time) (UUID, error) {
+	return DefaultGenerator().NewV7At(t)
+}
+
+// NewV8 generates a new UUID based on version 8 (custom data). Only the
+// version and variant bits are set, all other 122 bits are taken from
+// the custom data. Layout and uniqueness are up to the caller.
+func NewV8(custom [16]byte) UUID {
+	uuid := UUID(custom)
+
+	uuid.setVersion(V8)
+	uuid.setVariant()
+	return uuid
+}
+
+// NewV8Fields generates a new UUID based on version 8 out of the three
+// custom fields defined by RFC 9562. Only the lower 48 bits of customA,
+// the lower 12 bits of customB, and the lower 62 bits of customC are used.
+func NewV8Fields(customA uint64, customB uint16, customC uint64) UUID {
 	uuid := UUID{}
+	binary.BigEndian.PutUint64(uuid[0:8], customA<<16|uint64(customB&0x0fff))
+	binary.BigEndian.PutUint64(uuid[8:16], customC&0x3fffffffffffffff)
 
-	// Get time and sequence with monotonicity guarantee
-	ms, seq, err := getV7Time()
-	if err != nil {
-		return uuid, err
-	}
+	uuid.setVersion(V8)
+	uuid.setVariant()
+	return uuid
+}
 
-	// Fill first 48 bits with timestamp (milliseconds)
-	uuid[0] = byte(ms >> 40)
-	uuid[1] = byte(ms >> 32)
-	uuid[2] = byte(ms >> 24)
-	uuid[3] = byte(ms >> 16)
-	uuid[4] = byte(ms >> 8)
-	uuid[5] = byte(ms)
+// NewV8SHA256 generates a new name-based UUID of version 8 using the
+// SHA-256 hash of a namespace and a name as shown in RFC 9562 Appendix B.2.
+func NewV8SHA256(ns UUID, name []byte) (UUID, error) {
+	return newNameBased(sha256.New(), V8, ns, name)
+}
 
-	// Fill next 12 bits (after version) with sequence counter
-	// This ensures monotonicity within the same millisecond
-	uuid[6] = byte(seq >> 8)
-	uuid[7] = byte(seq)
+// NewV8SHA512 generates a new name-based UUID of version 8 using the
+// SHA-512 hash of a namespace and a name.
+func NewV8SHA512(ns UUID, name []byte) (UUID, error) {
+	return newNameBased(sha512.New(), V8, ns, name)
+}
 
-	// Fill remaining 62 bits (after variant) with random data
-	randData := make([]byte, 8)
-	if _, err := rand.Read(randData); err != nil {
-		return uuid, err
+// NewV8Hash generates a new name-based UUID of version 8 using the given
+// hash of a namespace and a name. The hash is reset before and has to
+// produce at least 16 bytes.
+func NewV8Hash(hash hash.Hash, ns UUID, name []byte) (UUID, error) {
+	if hash.Size() < 16 {
+		return UUID{}, fmt.Errorf("hash size of %d bytes too small", hash.Size())
 	}
-	uuid[8] = randData[0]
-	uuid[9] = randData[1]
-	uuid[10] = randData[2]
-	uuid[11] = randData[3]
-	uuid[12] = randData[4]
-	uuid[13] = randData[5]
-	uuid[14] = randData[6]
-	uuid[15] = randData[7]
+	hash.Reset()
+	return newNameBased(hash, V8, ns, name)
+}
+
+// MinV6 returns the smallest Version 6 UUID possible for the given time
+// with a precision of 100ns. Together with MaxV6 it allows range queries
+// on v6 keys, e.g. as bounds of WHERE id BETWEEN ? AND ?. Times out of
+// the range of v6 are clamped to the first respectively last timestamp.
+func MinV6(t time.Time) UUID {
+	uuid := UUID{}
+	uuid.setV6Time(gregorianTimestamp(t))
+
+	uuid.setVersion(V6)
+	uuid.setVariant()
+	return uuid
+}
+
+// MaxV6 returns the largest Version 6 UUID possible for the given time
+// with a precision of 100ns. Times out of the range of v6 are clamped
+// like in MinV6.
+func MaxV6(t time.Time) UUID {
+	uuid := maxUUID
+	uuid.setV6Time(gregorianTimestamp(t))
+
+	uuid.setVersion(V6)
+	uuid.setVariant()
+	return uuid
+}
+
+// MinV7 returns the smallest Version 7 UUID possible for the millisecond
+// of the given time. Together with MaxV7 it allows range queries on v7
+// keys, e.g. as bounds of WHERE id BETWEEN ? AND ?. Times out of the
+// range of v7 are clamped to the first respectively last millisecond.
+func MinV7(t time.Time) UUID {
+	uuid := UUID{}
+	uuid.setV7Time(unixMilli(t))
 
 	uuid.setVersion(V7)
 	uuid.setVariant()
-	return uuid, nil
+	return uuid
+}
+
+// MaxV7 returns the largest Version 7 UUID possible for the millisecond
+// of the given time. Times out of the range of v7 are clamped like in
+// MinV7.
+func MaxV7(t time.Time) UUID {
+	uuid := maxUUID
+	uuid.setV7Time(unixMilli(t))
+
+	uuid.setVersion(V7)
+	uuid.setVariant()
+	return uuid
 }
 
 // Parse creates a UUID based on the given hex string which has to have
@@ -288,45 +344,103 @@ func NewV7() (UUID, error) {
 // - {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}
 // - xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
 //
-// The net data always has to have the length of 32 bytes.
+// The net data always has to have the length of 32 bytes. Parsing
+// a valid source does not allocate memory.
 func Parse(source string) (UUID, error) {
-	var uuid UUID
-	var hexSource string
-	var err error
-	switch len(source) {
-	case 36:
-		hexSource, err = parseSource(source, "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx")
-	case 36 + 9:
-		hexSource, err = parseSource(source, "urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx")
-	case 36 + 2:
-		hexSource, err = parseSource(source, "{xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}")
-	case 32:
-		hexSource, err = parseSource(source, "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx")
-	default:
-		return uuid, fmt.Errorf("invalid source format: %q", source)
-	}
+	return parseHex(source)
+}
+
+// ParseBytes works like Parse but takes a byte slice, e.g. from a
+// network buffer or a log line, without converting it into a string.
+// Parsing a valid source does not allocate memory.
+func ParseBytes(source []byte) (UUID, error) {
+	return parseHex(source)
+}
+
+// ParseStrict works like Parse but additionally validates the UUID.
+// Its variant has to be the one of RFC 4122 and RFC 9562, its version
+// one of the known versions. If versions are passed the UUID has to be
+// of one of them. Errors wrap ErrInvalidFormat, ErrInvalidVariant, or
+// ErrInvalidVersion.
+//
+// The Nil and the Max UUID are valid if no versions are passed or
+// if VersionNil respectively VersionMax is part of them.
+func ParseStrict(source string, versions ...Version) (UUID, error) {
+	uuid, err := Parse(source)
 	if err != nil {
-		return uuid, err
+		return UUID{}, err
 	}
-	hexData, err := hex.DecodeString(hexSource)
-	if err != nil {
-		return uuid, fmt.Errorf("source is no hex value: %w", err)
+	if uuid.IsNil() || uuid.IsMax() {
+		if len(versions) > 0 && !slices.Contains(versions, uuid.Version()) {
+			return UUID{}, fmt.Errorf("%w: version %d is not in %v", ErrInvalidVersion, uuid.Version(), versions)
+		}
+		return uuid, nil
+	}
+	if uuid.Variant() != VariantRFC4122 {
+		return UUID{}, fmt.Errorf("%w: variant %d is not RFC 4122", ErrInvalidVariant, uuid.Variant())
+	}
+	if uuid.Version() < V1 || uuid.Version() > V8 {
+		return UUID{}, fmt.Errorf("%w: version %d is unknown", ErrInvalidVersion, uuid.Version())
+	}
+	if len(versions) > 0 && !slices.Contains(versions, uuid.Version()) {
+		return UUID{}, fmt.Errorf("%w: version %d is not in %v", ErrInvalidVersion, uuid.Version(), versions)
 	}
-	copy(uuid[:], hexData)
-	// TODO: Validate UUID (version, variant).
 	return uuid, nil
 }
 
-// Version returns the version number of the UUID algorithm.
+// Version returns the version number of the UUID algorithm. It
+// is VersionNil for the Nil UUID and VersionMax for the Max UUID.
 func (uuid UUID) Version() Version {
 	return Version(uuid[6] & 0xf0 >> 4)
 }
 
-// Variant returns the variant of the UUID.
+// Variant returns the variant of the UUID. The variant field has a
+// variable length of one to three bits, unused bits are reported as
+// zero. So the Nil UUID reports VariantNCS and the Max UUID reports
+// VariantFuture.
 func (uuid UUID) Variant() Variant {
+	switch {
+	case uuid[8]&0x80 == 0x00:
+		return VariantNCS
+	case uuid[8]&0xc0 == 0x80:
+		return VariantRFC4122
+	}
 	return Variant(uuid[8] & 0xe0 >> 5)
 }
 
+// IsNil returns true if the UUID is the Nil UUID with all bits
+// set to zero.
+func (uuid UUID) IsNil() bool {
+	return uuid == nilUUID
+}
+
+// IsMax returns true if the UUID is the Max UUID with all bits
+// set to one.
+func (uuid UUID) IsMax() bool {
+	return uuid == maxUUID
+}
+
+// Compare returns an integer comparing two UUIDs byte by byte. The
+// result is 0 if a == b, -1 if a < b, and +1 if a > b. It can be used
+// with slices.SortFunc and similar functions.
+//
+// For UUIDs of version 6 and 7 the byte order matches the creation
+// order, as the timestamp is stored from most to least significant
+// bit and followed by the monotonic clock sequence respectively counter.
+func Compare(a, b UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+// Less returns true if the UUID is ordered before the other one.
+func (uuid UUID) Less(other UUID) bool {
+	return Compare(uuid, other) < 0
+}
+
+// Equal returns true if both UUIDs are equal.
+func (uuid UUID) Equal(other UUID) bool {
+	return uuid == other
+}
+
 // Copy returns a copy of the UUID.
 func (uuid UUID) Copy() UUID {
 	uuidCopy := uuid
@@ -342,13 +456,33 @@ func (uuid UUID) Raw() [16]byte {
 // ShortString returns a hexadecimal string representation
 // without separators.
 func (uuid UUID) ShortString() string {
-	return fmt.Sprintf("%x", uuid[0:16])
+	var buf [32]byte
+	uuid.encodeHex(buf[:], false)
+	return string(buf[:])
 }
 
 // String returns a hexadecimal string representation with
 // standardized separators.
 func (uuid UUID) String() string {
-	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+	var buf [36]byte
+	uuid.encodeHex(buf[:], true)
+	return string(buf[:])
+}
+
+// AppendShortString appends the representation of ShortString to
+// dst. It does not allocate memory if dst has enough capacity.
+func (uuid UUID) AppendShortString(dst []byte) []byte {
+	var buf [32]byte
+	uuid.encodeHex(buf[:], false)
+	return append(dst, buf[:]...)
+}
+
+// AppendString appends the representation of String to dst. It
+// does not allocate memory if dst has enough capacity.
+func (uuid UUID) AppendString(dst []byte) []byte {
+	var buf [36]byte
+	uuid.encodeHex(buf[:], true)
+	return append(dst, buf[:]...)
 }
 
 // NamespaceDNS returns the DNS namespace UUID for a v3 or a v5.
@@ -387,6 +521,116 @@ func (uuid UUID) ID() uint32 {
 	return binary.BigEndian.Uint32(uuid[0:4])
 }
 
+// V8Fields returns the three custom fields of a Version 8 UUID as
+// defined by RFC 9562: 48 bits custom_a, 12 bits custom_b, and 62 bits
+// custom_c. It is the counterpart of NewV8Fields.
+func (uuid UUID) V8Fields() (customA uint64, customB uint16, customC uint64) {
+	high := binary.BigEndian.Uint64(uuid[0:8])
+	low := binary.BigEndian.Uint64(uuid[8:16])
+	return high >> 16, uint16(high & 0x0fff), low & 0x3fffffffffffffff
+}
+
+// ClockSequence returns the clock sequence of a time-based UUID.
+// Clock sequences are only defined for Version 1, 2, and 6 UUIDs.
+// Version 2 UUIDs only contain the 6 high bits, as the low byte
+// holds the domain. Version 1 UUIDs are read in the RFC layout, for
+// UUIDs created with WithLegacyV1 the returned value is wrong.
+func (uuid UUID) ClockSequence() uint16 {
+	if uuid.Version() == V2 {
+		return uint16(uuid[8] & 0x3f)
+	}
+	return binary.BigEndian.Uint16(uuid[8:10]) & 0x3fff
+}
+
+// NodeID returns the node identifier of a time-based UUID, typically
+// the MAC address of the generating host.
+// Node IDs are only defined for Version 1, 2, and 6 UUIDs.
+func (uuid UUID) NodeID() [6]byte {
+	return [6]byte(uuid[10:16])
+}
+
+// Counter returns the 12-bit rand_a field of a Version 7 UUID. With the
+// default V7Counter method this package uses it as sequence counter for
+// UUIDs generated within the same millisecond. With V7LongCounter it
+// contains the upper 12 bits of the counter, with V7SubMillisecond the
+// fraction of the millisecond.
+func (uuid UUID) Counter() uint16 {
+	return binary.BigEndian.Uint16(uuid[6:8]) & 0x0fff
+}
+
+// LegacyV1Time returns the timestamp of a Version 1 UUID created in the
+// former little-endian layout of this package, see WithLegacyV1. This
+// layout lost the bits 52 to 55 of the timestamp to the version. They
+// are recovered for the latest time not after now.
+func (uuid UUID) LegacyV1Time() (time.Time, error) {
+	if uuid.Version() != V1 {
+		return time.Time{}, fmt.Errorf("%w: UUID version %d is no legacy version 1", ErrInvalidVersion, uuid.Version())
+	}
+	timeLow := uint64(binary.LittleEndian.Uint32(uuid[0:4]))
+	timeMid := uint64(binary.LittleEndian.Uint16(uuid[4:6]))
+	timestamp := uint64(uuid[7]&0x0f)<<56 | uint64(uuid[6]&0x0f)<<48 | timeMid<<32 | timeLow
+	now := gregorianTimestamp(time.Now())
+	for lost := uint64(0x0f); lost > 0; lost-- {
+		if candidate := timestamp | lost<<52; candidate <= now {
+			return gregorianTime(candidate), nil
+		}
+	}
+	return gregorianTime(timestamp), nil
+}
+
+// Time returns the timestamp embedded in a time-based UUID. For
+// versions 1 and 6 it is the 60-bit Gregorian timestamp with a
+// precision of 100ns, for version 7 the 48-bit Unix timestamp in
+// milliseconds. Version 2 only contains the upper 28 bits of the
+// Gregorian timestamp, so the time is truncated to a precision of
+// about 7 minutes. Other versions contain no time and return an error.
+// Version 1 UUIDs created with WithLegacyV1 need LegacyV1Time instead.
+func (uuid UUID) Time() (time.Time, error) {
+	switch uuid.Version() {
+	case V1:
+		return gregorianTime(uuid.v1Time()), nil
+	case V2:
+		return gregorianTime(uuid.v1Time() &^ 0xffffffff), nil
+	case V6:
+		return gregorianTime(uuid.v6Time()), nil
+	case V7:
+		ms := int64(uuid[0])<<40 | int64(uuid[1])<<32 | int64(uuid[2])<<24 |
+			int64(uuid[3])<<16 | int64(uuid[4])<<8 | int64(uuid[5])
+		return time.UnixMilli(ms), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UUID version %d contains no time", ErrInvalidVersion, uuid.Version())
+}
+
+// ToV6 converts a Version 1 UUID into a Version 6 UUID. The fields of
+// the timestamp are reordered, clock sequence and node are kept. So the
+// conversion is lossless and can be reverted with ToV1. It only applies
+// to the RFC layout, UUIDs created with WithLegacyV1 are not detected
+// and result in a wrong timestamp and clock sequence.
+func (uuid UUID) ToV6() (UUID, error) {
+	if uuid.Version() != V1 {
+		return UUID{}, fmt.Errorf("%w: cannot convert version %d to version 6", ErrInvalidVersion, uuid.Version())
+	}
+	uuidV6 := uuid
+	uuidV6.setV6Time(uuid.v1Time())
+
+	uuidV6.setVersion(V6)
+	return uuidV6, nil
+}
+
+// ToV1 converts a Version 6 UUID into a Version 1 UUID. The fields of
+// the timestamp are reordered, clock sequence and node are kept. So the
+// conversion is lossless and can be reverted with ToV6.
+func (uuid UUID) ToV1() (UUID, error) {
+	if uuid.Version() != V6 {
+		return UUID{}, fmt.Errorf("%w: cannot convert version %d to version 1", ErrInvalidVersion, uuid.Version())
+	}
+	uuidV1 := uuid
+	uuidV1.setV1Time(uuid.v6Time())
+
+	uuidV1.setVersion(V1)
+	return uuidV1, nil
+}
+
 // String returns the string representation of a Domain.
 func (d Domain) String() string {
 	switch d {
@@ -413,6 +657,48 @@ func (uuid UUID) dump() []byte {
 	return dump
 }
 
+// v1Time returns the 60-bit Gregorian timestamp of a Version 1 UUID.
+func (uuid UUID) v1Time() uint64 {
+	timeLow := uint64(binary.BigEndian.Uint32(uuid[0:4]))
+	timeMid := uint64(binary.BigEndian.Uint16(uuid[4:6]))
+	timeHigh := uint64(binary.BigEndian.Uint16(uuid[6:8]) & 0x0fff)
+	return timeHigh<<48 | timeMid<<32 | timeLow
+}
+
+// v6Time returns the 60-bit Gregorian timestamp of a Version 6 UUID.
+func (uuid UUID) v6Time() uint64 {
+	timeHigh := uint64(binary.BigEndian.Uint32(uuid[0:4]))
+	timeMid := uint64(binary.BigEndian.Uint16(uuid[4:6]))
+	timeLow := uint64(binary.BigEndian.Uint16(uuid[6:8]) & 0x0fff)
+	return timeHigh<<28 | timeMid<<12 | timeLow
+}
+
+// setV1Time sets the 60-bit Gregorian timestamp of a Version 1 UUID
+// stored as time_low, time_mid, and time_high. The version is not set.
+func (uuid *UUID) setV1Time(timestamp uint64) {
+	binary.BigEndian.PutUint32(uuid[0:4], uint32(timestamp))
+	binary.BigEndian.PutUint16(uuid[4:6], uint16(timestamp>>32))
+	binary.BigEndian.PutUint16(uuid[6:8], uint16(timestamp>>48)&0x0fff|uint16(uuid[6]&0xf0)<<8)
+}
+
+// setV6Time sets the 60-bit Gregorian timestamp of a Version 6 UUID
+// stored from most to least significant bits. The version is not set.
+func (uuid *UUID) setV6Time(timestamp uint64) {
+	binary.BigEndian.PutUint32(uuid[0:4], uint32(timestamp>>28))
+	binary.BigEndian.PutUint16(uuid[4:6], uint16(timestamp>>12))
+	binary.BigEndian.PutUint16(uuid[6:8], uint16(timestamp&0x0fff)|uint16(uuid[6]&0xf0)<<8)
+}
+
+// setV7Time sets the 48-bit Unix millisecond timestamp of a Version 7 UUID.
+func (uuid *UUID) setV7Time(ms int64) {
+	uuid[0] = byte(ms >> 40)
+	uuid[1] = byte(ms >> 32)
+	uuid[2] = byte(ms >> 24)
+	uuid[3] = byte(ms >> 16)
+	uuid[4] = byte(ms >> 8)
+	uuid[5] = byte(ms)
+}
+
 // setVersion sets the version part of the UUID.
 func (uuid *UUID) setVersion(v Version) {
 	uuid[6] = (uuid[6] & 0x0f) | (byte(v) << 4)
@@ -420,192 +706,148 @@ func (uuid *UUID) setVersion(v Version) {
 
 // setVariant sets the variant part of the UUID, always RFC4122.
 // Used to keep source more consistent with version and variant.
+// The RFC4122 variant only occupies the two high bits.
 func (uuid *UUID) setVariant() {
-	uuid[8] = (uuid[8] & 0x1f) | (byte(VariantRFC4122) << 5)
-}
-
-// parseSource parses a source based on the given pattern. Only the
-// char x of the pattern is interpreted as hex char. If the result is
-// longer than 32 bytes it's an error.
-func parseSource(source, pattern string) (string, error) {
-	lower := []byte(strings.ToLower(source))
-	raw := make([]byte, 32)
-	rawPos := 0
-	patternPos := 0
-	patternLen := len(pattern)
-	for i, b := range lower {
-		if patternPos == patternLen {
-			return "", fmt.Errorf("source %q too long for pattern %q", source, pattern)
-		}
-		switch pattern[patternPos] {
-		case 'x':
-			if (b < '0' || b > '9') && (b < 'a' || b > 'f') {
-				return "", fmt.Errorf("source char %d is no hex char: %c", i, b)
-			}
-			raw[rawPos] = b
-			rawPos++
-			patternPos++
-		default:
-			if b != pattern[patternPos] {
-				return "", fmt.Errorf("source char %d does not match pattern: %x is not %c", i, b, pattern[patternPos])
-			}
-			patternPos++
-		}
-	}
-	return string(raw), nil
-}
-
-// macAddress retrieves the MAC address of the computer.
-func macAddress() []byte {
-	address := [6]byte{}
-	ifaces, err := net.Interfaces()
-	// Try to get address from interfaces.
-	if err == nil {
-		set := false
-		for _, iface := range ifaces {
-			if len(iface.HardwareAddr.String()) != 0 {
-				copy(address[:], []byte(iface.HardwareAddr))
-				set = true
-				break
-			}
-		}
-		if set {
-			// Had success.
-			return address[:]
-		}
-	}
-	// Need a random address.
-	if _, err := rand.Read(address[:]); err != nil {
-		panic(err)
-	}
-	address[0] |= 0x01
-	return address[:]
+	uuid[8] = (uuid[8] & 0x3f) | (byte(VariantRFC4122) << 5)
 }
 
-var cachedMACAddress []byte
-
-func init() {
-	cachedMACAddress = macAddress()
-}
-
-// v6State holds the state for monotonic UUID v6 generation.
-type v6State struct {
-	mu           sync.Mutex
-	lastTime     uint64 // Last timestamp (in 100ns intervals)
-	lastClockSeq uint16 // Last clock sequence
-}
+// newNameBased creates a name-based UUID with the given hash and version.
+func newNameBased(hash hash.Hash, v Version, ns UUID, name []byte) (UUID, error) {
+	uuid := UUID{}
+	if _, err := hash.Write(ns.dump()); err != nil {
+		return uuid, err
+	}
+	if _, err := hash.Write(name); err != nil {
+		return uuid, err
+	}
+	copy(uuid[:], hash.Sum([]byte{})[:16])
 
-// v7State holds the state for monotonic UUID v7 generation.
-type v7State struct {
-	mu      sync.Mutex
-	lastMs  int64  // Last millisecond timestamp
-	lastSeq uint16 // Last sequence number
+	uuid.setVersion(v)
+	uuid.setVariant()
+	return uuid, nil
 }
 
-var v6Generator = &v6State{}
-var v7Generator = &v7State{}
-
-// getV7Time returns the current time in milliseconds and a monotonic sequence number.
-// The returned values ensure that each UUID v7 is greater than the previous one,
-// even when multiple UUIDs are generated within the same millisecond.
-func getV7Time() (ms int64, seq uint16, err error) {
-	v7Generator.mu.Lock()
-	defer v7Generator.mu.Unlock()
+// Patterns of the formats accepted by Parse. Only the char x is
+// interpreted as hex char.
+const (
+	patternStandard = "xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+	patternURN      = "urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
+	patternBraced   = "{xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}"
+	patternShort    = "xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx"
+)
 
-	// Get current time in milliseconds - capture once to avoid inconsistencies
-	now := time.Now().UnixMilli()
+// hexChars are the lower case hex digits used for formatting.
+const hexChars = "0123456789abcdef"
 
-	switch {
-	case now == v7Generator.lastMs:
-		// Same millisecond: increment sequence
-		if v7Generator.lastSeq == 0x0FFF {
-			// Sequence overflow - this is extremely rare but we should handle it
-			// Wait for the next millisecond
-			for {
-				time.Sleep(time.Microsecond * 100)
-				newNow := time.Now().UnixMilli()
-				if newNow > now {
-					now = newNow
-					break
-				}
+// parseHex parses a source in one of the formats of Parse without
+// allocating memory. Letters are accepted in upper and lower case.
+func parseHex[S string | []byte](source S) (UUID, error) {
+	var uuid UUID
+	var pattern string
+	switch len(source) {
+	case len(patternStandard):
+		pattern = patternStandard
+	case len(patternURN):
+		pattern = patternURN
+	case len(patternBraced):
+		pattern = patternBraced
+	case len(patternShort):
+		pattern = patternShort
+	default:
+		return uuid, fmt.Errorf("%w: invalid source format: %q", ErrInvalidFormat, string(source))
+	}
+	nibble := 0
+	for i := 0; i < len(source); i++ {
+		b := source[i]
+		if b >= 'A' && b <= 'Z' {
+			b += 'a' - 'A'
+		}
+		if pattern[i] != 'x' {
+			if b != pattern[i] {
+				return UUID{}, fmt.Errorf("%w: source char %d does not match pattern: %x is not %c", ErrInvalidFormat, i, b, pattern[i])
 			}
-			// Start with sequence 0 for new millisecond after overflow
-			v7Generator.lastSeq = 0
-			v7Generator.lastMs = now
-		} else {
-			v7Generator.lastSeq++
+			continue
 		}
-	case now > v7Generator.lastMs:
-		// New millisecond: initialize with random sequence
-		randBytes := make([]byte, 2)
-		if _, err := rand.Read(randBytes); err != nil {
-			return 0, 0, err
+		var value byte
+		switch {
+		case b >= '0' && b <= '9':
+			value = b - '0'
+		case b >= 'a' && b <= 'f':
+			value = b - 'a' + 10
+		default:
+			return UUID{}, fmt.Errorf("%w: source char %d is no hex char: %c", ErrInvalidFormat, i, b)
 		}
-		v7Generator.lastSeq = binary.BigEndian.Uint16(randBytes) & 0x0FFF
-		v7Generator.lastMs = now
-	default:
-		// Clock went backwards - this is problematic
-		// Use the last known time and increment sequence
-		if v7Generator.lastSeq == 0x0FFF {
-			v7Generator.lastSeq = 0
-			v7Generator.lastMs++
-		} else {
-			v7Generator.lastSeq++
+		uuid[nibble/2] |= value << (4 * (1 - nibble%2))
+		nibble++
+	}
+	return uuid, nil
+}
+
+// encodeHex writes the hexadecimal representation of the UUID into
+// dst. With dashes it needs 36 bytes, otherwise 32 bytes.
+func (uuid UUID) encodeHex(dst []byte, dashes bool) {
+	pos := 0
+	for i, b := range uuid {
+		if dashes && (i == 4 || i == 6 || i == 8 || i == 10) {
+			dst[pos] = '-'
+			pos++
 		}
-		now = v7Generator.lastMs
+		dst[pos] = hexChars[b>>4]
+		dst[pos+1] = hexChars[b&0x0f]
+		pos += 2
 	}
+}
 
-	return now, v7Generator.lastSeq, nil
+// gregorianTimestamp converts a time.Time into a timestamp in 100ns
+// intervals since the Gregorian epoch. Times out of range are clamped.
+func gregorianTimestamp(t time.Time) uint64 {
+	timestamp, err := checkedGregorianTimestamp(t)
+	if err != nil {
+		if t.Unix() < 0 {
+			return 0
+		}
+		return maxGregorianTimestamp
+	}
+	return timestamp
 }
 
-// getV6ClockSeq returns a monotonic clock sequence for UUID v6 generation.
-// The returned clock sequence ensures that each UUID v6 is greater than the previous one,
-// even when multiple UUIDs are generated within the same timestamp period.
-func getV6ClockSeq(timestamp uint64) (adjustedTimestamp uint64, clockSeq uint16, err error) {
-	v6Generator.mu.Lock()
-	defer v6Generator.mu.Unlock()
+// checkedGregorianTimestamp converts a time.Time into a timestamp in
+// 100ns intervals since the Gregorian epoch. It is calculated from
+// seconds and nanoseconds, as UnixNano is only defined for the years
+// 1678 to 2262.
+func checkedGregorianTimestamp(t time.Time) (uint64, error) {
+	secs := t.Unix() + gregorianEpoch/10000000
+	if secs < 0 || secs > maxGregorianTimestamp/10000000 {
+		return 0, fmt.Errorf("%w: %v is not within the 60-bit Gregorian timestamp", ErrTimeOutOfRange, t)
+	}
+	timestamp := uint64(secs)*1e7 + uint64(t.Nanosecond()/100)
+	if timestamp > maxGregorianTimestamp {
+		return 0, fmt.Errorf("%w: %v is not within the 60-bit Gregorian timestamp", ErrTimeOutOfRange, t)
+	}
+	return timestamp, nil
+}
 
-	switch {
-	case timestamp == v6Generator.lastTime:
-		// Same timestamp: increment clock sequence
-		if v6Generator.lastClockSeq == 0x3FFF {
-			// Clock sequence overflow - this is extremely rare
-			// Wait for the next timestamp unit (100ns)
-			for timestamp == v6Generator.lastTime {
-				time.Sleep(time.Nanosecond * 100)
-				epoch := int64(0x01b21dd213814000)
-				timestamp = uint64(time.Now().UnixNano()/100 + epoch)
-			}
-			// Initialize new clock sequence with random value
-			randBytes := make([]byte, 2)
-			if _, err := rand.Read(randBytes); err != nil {
-				return 0, 0, err
-			}
-			v6Generator.lastClockSeq = binary.BigEndian.Uint16(randBytes) & 0x3FFF
-		} else {
-			v6Generator.lastClockSeq++
-		}
-	case timestamp > v6Generator.lastTime:
-		// New timestamp: initialize with random clock sequence
-		randBytes := make([]byte, 2)
-		if _, err := rand.Read(randBytes); err != nil {
-			return 0, 0, err
-		}
-		v6Generator.lastClockSeq = binary.BigEndian.Uint16(randBytes) & 0x3FFF
-		v6Generator.lastTime = timestamp
-	default:
-		// Clock went backwards - this is problematic
-		// Use the last known time and increment clock sequence
-		if v6Generator.lastClockSeq == 0x3FFF {
-			v6Generator.lastClockSeq = 0
-			v6Generator.lastTime++
-		} else {
-			v6Generator.lastClockSeq++
-		}
-		timestamp = v6Generator.lastTime
+// unixMilli converts a time.Time into the 48-bit Unix millisecond
+// timestamp of Version 7 UUIDs. Times out of range are clamped.
+func unixMilli(t time.Time) int64 {
+	return min(max(t.UnixMilli(), 0), maxUnixMilli)
+}
+
+// checkedUnixMilli converts a time.Time into the 48-bit Unix millisecond
+// timestamp of Version 7 UUIDs.
+func checkedUnixMilli(t time.Time) (int64, error) {
+	ms := t.UnixMilli()
+	if ms < 0 || ms > maxUnixMilli {
+		return 0, fmt.Errorf("%w: %v is not within the 48-bit Unix timestamp", ErrTimeOutOfRange, t)
 	}
+	return ms, nil
+}
 
-	return timestamp, v6Generator.lastClockSeq, nil
+// gregorianTime converts a timestamp in 100ns intervals since the
+// Gregorian epoch into a time.Time.
+func gregorianTime(timestamp uint64) time.Time {
+	unix := int64(timestamp) - gregorianEpoch
+	return time.Unix(unix/1e7, (unix%1e7)*100)
 }
 
 // EOF
@@ -8,7 +8,13 @@
 package uuid_test
 
 import (
+	"crypto/sha256"
+	"errors"
+	"hash/fnv"
+	"math/rand/v2"
+	"slices"
 	"testing"
+	"time"
 
 	"tideland.dev/go/asserts/verify"
 
@@ -92,6 +98,12 @@ func TestVersions(t *testing.T) {
 	verify.Equal(t, uuidV7.Version(), uuid.V7)
 	verify.Equal(t, uuidV7.Variant(), uuid.VariantRFC4122)
 	t.Logf("UUID V7: %v", uuidV7)
+
+	// Test UUID v8
+	uuidV8 := uuid.NewV8([16]byte{1, 3, 3, 7})
+	verify.Equal(t, uuidV8.Version(), uuid.V8)
+	verify.Equal(t, uuidV8.Variant(), uuid.VariantRFC4122)
+	t.Logf("UUID V8: %v", uuidV8)
 }
 
 // TestParse tests creating UUIDs from different string representations.
@@ -143,6 +155,98 @@ func TestParse(t *testing.T) {
 	}
 }
 
+// TestParseStrict tests parsing with validation of version and variant.
+func TestParseStrict(t *testing.T) {
+	uuidV4 := uuid.New().String()
+	uuidV7, err := uuid.NewV7()
+	verify.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		source   string
+		versions []uuid.Version
+		err      error
+	}{
+		{"v4", uuidV4, nil, nil},
+		{"v7", uuidV7.String(), nil, nil},
+		{"v7-allowed", uuidV7.String(), []uuid.Version{uuid.V6, uuid.V7}, nil},
+		{"v8-rfc", "5c146b14-3c52-8afd-938a-375d0df1fbf6", nil, nil},
+		{"v4-not-allowed", uuidV4, []uuid.Version{uuid.V6, uuid.V7}, uuid.ErrInvalidVersion},
+		{"unknown-version", "123e4567-e89b-92d3-a456-426614174000", nil, uuid.ErrInvalidVersion},
+		{"zero-version", "123e4567-e89b-02d3-a456-426614174000", nil, uuid.ErrInvalidVersion},
+		{"ncs-variant", "123e4567-e89b-42d3-7456-426614174000", nil, uuid.ErrInvalidVariant},
+		{"microsoft-variant", "123e4567-e89b-42d3-c456-426614174000", nil, uuid.ErrInvalidVariant},
+		{"future-variant", "123e4567-e89b-42d3-e456-426614174000", nil, uuid.ErrInvalidVariant},
+		{"invalid-length", "123e4567", nil, uuid.ErrInvalidFormat},
+		{"invalid-hex", "123e4567-e89b-42d3-a456-42661417400x", nil, uuid.ErrInvalidFormat},
+		{"invalid-separator", "123e4567+e89b-42d3-a456-426614174000", nil, uuid.ErrInvalidFormat},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u, err := uuid.ParseStrict(test.source, test.versions...)
+			if test.err == nil {
+				verify.NoError(t, err)
+				verify.Equal(t, u.String(), test.source)
+			} else {
+				verify.True(t, errors.Is(err, test.err), "error must be "+test.err.Error())
+				verify.Equal(t, u, uuid.UUID{})
+			}
+		})
+	}
+
+	// Lenient parsing keeps accepting all of them
+	_, err = uuid.Parse("123e4567-e89b-92d3-e456-426614174000")
+	verify.NoError(t, err)
+}
+
+// TestNilAndMax tests the special Nil and Max UUIDs.
+func TestNilAndMax(t *testing.T) {
+	verify.Equal(t, uuid.Nil.String(), "00000000-0000-0000-0000-000000000000")
+	verify.True(t, uuid.Nil.IsNil())
+	verify.False(t, uuid.Nil.IsMax())
+	verify.Equal(t, uuid.Nil.Version(), uuid.VersionNil)
+	verify.Equal(t, uuid.Nil.Variant(), uuid.VariantNCS)
+
+	verify.Equal(t, uuid.Max.String(), "ffffffff-ffff-ffff-ffff-ffffffffffff")
+	verify.True(t, uuid.Max.IsMax())
+	verify.False(t, uuid.Max.IsNil())
+	verify.Equal(t, uuid.Max.Version(), uuid.VersionMax)
+	verify.Equal(t, uuid.Max.Variant(), uuid.VariantFuture)
+
+	var unset uuid.UUID
+	verify.True(t, unset.IsNil())
+	verify.False(t, uuid.New().IsNil())
+	verify.False(t, uuid.New().IsMax())
+
+	// Strict parsing accepts them unless versions exclude them
+	u, err := uuid.ParseStrict("00000000-0000-0000-0000-000000000000")
+	verify.NoError(t, err)
+	verify.True(t, u.IsNil())
+	u, err = uuid.ParseStrict("FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF")
+	verify.NoError(t, err)
+	verify.True(t, u.IsMax())
+	u, err = uuid.ParseStrict(uuid.Nil.String(), uuid.V7, uuid.VersionNil)
+	verify.NoError(t, err)
+	verify.True(t, u.IsNil())
+	_, err = uuid.ParseStrict(uuid.Nil.String(), uuid.V7)
+	verify.True(t, errors.Is(err, uuid.ErrInvalidVersion))
+	_, err = uuid.ParseStrict(uuid.Max.String(), uuid.V7, uuid.VersionNil)
+	verify.True(t, errors.Is(err, uuid.ErrInvalidVersion))
+
+	// Changing the exported values does not affect the package
+	defer func(nilUUID, maxUUID uuid.UUID) {
+		uuid.Nil, uuid.Max = nilUUID, maxUUID
+	}(uuid.Nil, uuid.Max)
+	uuid.Nil[0] = 0xff
+	uuid.Max[0] = 0x00
+	verify.True(t, unset.IsNil())
+	u, err = uuid.Parse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	verify.NoError(t, err)
+	verify.True(t, u.IsMax())
+	verify.Equal(t, uuid.MaxV7(time.UnixMilli(0)).String(), "00000000-0000-7fff-bfff-ffffffffffff")
+}
+
 // TestV2DCESecurity tests DCE Security UUID generation and extraction.
 func TestV2DCESecurity(t *testing.T) {
 	// Test with different domains and IDs
@@ -288,6 +392,101 @@ func TestV7Monotonicity(t *testing.T) {
 	t.Logf("Successfully generated %d unique, monotonic UUIDs", len(uuids))
 }
 
+// TestCompare tests the ordering of UUIDs.
+func TestCompare(t *testing.T) {
+	a := uuid.NewV8Fields(1, 0, 0)
+	b := uuid.NewV8Fields(2, 0, 0)
+
+	verify.Equal(t, uuid.Compare(a, b), -1)
+	verify.Equal(t, uuid.Compare(b, a), 1)
+	verify.Equal(t, uuid.Compare(a, a), 0)
+	verify.True(t, a.Less(b))
+	verify.False(t, b.Less(a))
+	verify.False(t, a.Less(a))
+	verify.True(t, a.Equal(a.Copy()))
+	verify.False(t, a.Equal(b))
+	verify.True(t, uuid.Nil.Less(a))
+	verify.True(t, b.Less(uuid.Max))
+
+	// Sorting shuffled v6 and v7 UUIDs restores the creation order
+	for _, newUUID := range []func() (uuid.UUID, error){uuid.NewV6, uuid.NewV7} {
+		created := make([]uuid.UUID, 100)
+		for i := range created {
+			u, err := newUUID()
+			verify.NoError(t, err)
+			created[i] = u
+		}
+		verify.True(t, slices.IsSortedFunc(created, uuid.Compare))
+
+		sorted := slices.Clone(created)
+		rand.Shuffle(len(sorted), func(i, j int) {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		})
+		slices.SortFunc(sorted, uuid.Compare)
+		verify.True(t, slices.Equal(sorted, created), "sorting restores creation order")
+	}
+}
+
+// TestTimeBounds tests the smallest and largest UUIDs for a time.
+func TestTimeBounds(t *testing.T) {
+	now := time.Date(2025, time.December, 24, 18, 30, 15, 123456789, time.UTC)
+	gen := uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithRandom(&sequenceReader{}),
+	)
+
+	// Test v7 bounds
+	minV7 := uuid.MinV7(now)
+	maxV7 := uuid.MaxV7(now)
+	verify.Equal(t, minV7.Version(), uuid.V7)
+	verify.Equal(t, minV7.Variant(), uuid.VariantRFC4122)
+	verify.Equal(t, maxV7.Version(), uuid.V7)
+	verify.Equal(t, maxV7.Variant(), uuid.VariantRFC4122)
+	verify.Equal(t, minV7.String(), "019b51a0-3f53-7000-8000-000000000000")
+	verify.Equal(t, maxV7.String(), "019b51a0-3f53-7fff-bfff-ffffffffffff")
+	ts, err := maxV7.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now.Truncate(time.Millisecond)), "max v7 time is truncated time")
+
+	for range 100 {
+		u, err := gen.NewV7()
+		verify.NoError(t, err)
+		verify.True(t, uuid.Compare(minV7, u) <= 0, "v7 not below min")
+		verify.True(t, uuid.Compare(u, maxV7) <= 0, "v7 not above max")
+	}
+	verify.True(t, uuid.MaxV7(now.Add(-time.Millisecond)).Less(minV7))
+	verify.True(t, maxV7.Less(uuid.MinV7(now.Add(time.Millisecond))))
+
+	// Times out of range are clamped
+	past := time.Date(1960, time.January, 1, 0, 0, 0, 0, time.UTC)
+	verify.Equal(t, uuid.MinV7(past), uuid.MinV7(time.UnixMilli(0)))
+	verify.Equal(t, uuid.MaxV7(past), uuid.MaxV7(time.UnixMilli(0)))
+	future := time.Date(20000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	verify.Equal(t, uuid.MaxV7(future).String(), "ffffffff-ffff-7fff-bfff-ffffffffffff")
+	verify.Equal(t, uuid.MinV6(time.Date(1500, time.January, 1, 0, 0, 0, 0, time.UTC)).String(), "00000000-0000-6000-8000-000000000000")
+	verify.Equal(t, uuid.MaxV6(future).String(), "ffffffff-ffff-6fff-bfff-ffffffffffff")
+
+	// Test v6 bounds
+	minV6 := uuid.MinV6(now)
+	maxV6 := uuid.MaxV6(now)
+	verify.Equal(t, minV6.Version(), uuid.V6)
+	verify.Equal(t, minV6.Variant(), uuid.VariantRFC4122)
+	verify.Equal(t, maxV6.Version(), uuid.V6)
+	verify.Equal(t, maxV6.Variant(), uuid.VariantRFC4122)
+	ts, err = minV6.Time()
+	verify.NoError(t, err)
+	verify.True(t, ts.Equal(now.Truncate(100*time.Nanosecond)), "min v6 time is truncated time")
+	verify.Equal(t, minV6.ClockSequence(), uint16(0))
+	verify.Equal(t, maxV6.ClockSequence(), uint16(0x3fff))
+
+	u, err := gen.NewV6()
+	verify.NoError(t, err)
+	verify.True(t, uuid.Compare(minV6, u) <= 0, "v6 not below min")
+	verify.True(t, uuid.Compare(u, maxV6) <= 0, "v6 not above max")
+	verify.True(t, uuid.MaxV6(now.Add(-100*time.Nanosecond)).Less(minV6))
+	verify.True(t, maxV6.Less(uuid.MinV6(now.Add(100*time.Nanosecond))))
+}
+
 // TestConcurrentGeneration tests concurrent UUID generation.
 func TestConcurrentGeneration(t *testing.T) {
 	const goroutines = 10
@@ -339,6 +538,26 @@ func TestNamespaceStability(t *testing.T) {
 	// All should be identical
 	verify.Equal(t, uuid1.String(), uuid2.String())
 	verify.Equal(t, uuid2.String(), uuid3.String())
+
+	// Compare with reference values, including names where the third
+	// bit of the variant byte is part of the hash
+	tests := []struct {
+		name string
+		v3   string
+		v5   string
+	}{
+		{"www.example.com", "5df41881-3aed-3515-88a7-2f4a814cf09e", "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+		{"python.org", "6fa459ea-ee8a-3ca4-894e-db77e160355e", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{"tideland.dev", "b1351add-267d-386f-be9e-dd3479457e25", "73329155-b2bf-5146-aa16-cf2d761d65ca"},
+	}
+	for _, test := range tests {
+		uuidV3, err := uuid.NewV3(ns, []byte(test.name))
+		verify.NoError(t, err)
+		verify.Equal(t, uuidV3.String(), test.v3)
+		uuidV5, err := uuid.NewV5(ns, []byte(test.name))
+		verify.NoError(t, err)
+		verify.Equal(t, uuidV5.String(), test.v5)
+	}
 }
 
 // TestRawAndCopy tests Raw and Copy methods.
@@ -364,6 +583,247 @@ func TestRawAndCopy(t *testing.T) {
 	verify.Equal(t, uuidCopy.String(), original)
 }
 
+// TestV8Fields tests packing and unpacking the custom fields of v8 UUIDs.
+func TestV8Fields(t *testing.T) {
+	// Test vector of RFC 9562 Appendix B.1
+	u := uuid.NewV8Fields(0x2489e9ad2ee2, 0x0e00, 0x0ec932d5f69181c0)
+	verify.Equal(t, u.String(), "2489e9ad-2ee2-8e00-8ec9-32d5f69181c0")
+	customA, customB, customC := u.V8Fields()
+	verify.Equal(t, customA, uint64(0x2489e9ad2ee2))
+	verify.Equal(t, customB, uint16(0x0e00))
+	verify.Equal(t, customC, uint64(0x0ec932d5f69181c0))
+
+	// Test exceeding bits are dropped
+	u = uuid.NewV8Fields(0xffffffffffffffff, 0xffff, 0xffffffffffffffff)
+	verify.Equal(t, u.String(), "ffffffff-ffff-8fff-bfff-ffffffffffff")
+	customA, customB, customC = u.V8Fields()
+	verify.Equal(t, customA, uint64(0xffffffffffff))
+	verify.Equal(t, customB, uint16(0x0fff))
+	verify.Equal(t, customC, uint64(0x3fffffffffffffff))
+
+	// Test custom data only loses version and variant bits
+	custom := [16]byte{}
+	for i := range custom {
+		custom[i] = 0xff
+	}
+	u = uuid.NewV8(custom)
+	verify.Equal(t, u.String(), "ffffffff-ffff-8fff-bfff-ffffffffffff")
+}
+
+// TestV8NameBased tests the name-based v8 UUIDs.
+func TestV8NameBased(t *testing.T) {
+	ns := uuid.NamespaceDNS()
+	name := []byte("www.example.com")
+
+	// Test vector of RFC 9562 Appendix B.2
+	uuidSHA256, err := uuid.NewV8SHA256(ns, name)
+	verify.NoError(t, err)
+	verify.Equal(t, uuidSHA256.String(), "5c146b14-3c52-8afd-938a-375d0df1fbf6")
+	verify.Equal(t, uuidSHA256.Version(), uuid.V8)
+	verify.Equal(t, uuidSHA256.Variant(), uuid.VariantRFC4122)
+
+	uuidSHA512, err := uuid.NewV8SHA512(ns, name)
+	verify.NoError(t, err)
+	verify.Equal(t, uuidSHA512.String(), "94ee4ddb-9f36-8018-9ccf-86a4441691e0")
+	verify.Equal(t, uuidSHA512.Version(), uuid.V8)
+	verify.Equal(t, uuidSHA512.Variant(), uuid.VariantRFC4122)
+
+	// Test pluggable hash, also when already used
+	hash := sha256.New()
+	hash.Write([]byte("dirty"))
+	uuidHash, err := uuid.NewV8Hash(hash, ns, name)
+	verify.NoError(t, err)
+	verify.Equal(t, uuidHash, uuidSHA256)
+
+	_, err = uuid.NewV8Hash(fnv.New64(), ns, name)
+	verify.ErrorContains(t, err, "hash size of 8 bytes too small")
+}
+
+// TestConvertV1V6 tests the conversion between v1 and v6 UUIDs.
+func TestConvertV1V6(t *testing.T) {
+	// Test vectors of RFC 9562 Appendix A
+	uuidV1, err := uuid.Parse("C232AB00-9414-11EC-B3C8-9F6BDECED846")
+	verify.NoError(t, err)
+	uuidV6, err := uuid.Parse("1EC9414C-232A-6B00-B3C8-9F6BDECED846")
+	verify.NoError(t, err)
+
+	converted, err := uuidV1.ToV6()
+	verify.NoError(t, err)
+	verify.Equal(t, converted, uuidV6)
+	converted, err = uuidV6.ToV1()
+	verify.NoError(t, err)
+	verify.Equal(t, converted, uuidV1)
+
+	// Test round-trip of generated UUIDs
+	for range 100 {
+		u, err := uuid.NewV1()
+		verify.NoError(t, err)
+		v6, err := u.ToV6()
+		verify.NoError(t, err)
+		verify.Equal(t, v6.Version(), uuid.V6)
+		verify.Equal(t, v6.Variant(), uuid.VariantRFC4122)
+		verify.Equal(t, v6.ClockSequence(), u.ClockSequence())
+		verify.Equal(t, v6.NodeID(), u.NodeID())
+		tsV1, err := u.Time()
+		verify.NoError(t, err)
+		tsV6, err := v6.Time()
+		verify.NoError(t, err)
+		verify.True(t, tsV1.Equal(tsV6), "conversion keeps time")
+		v1, err := v6.ToV1()
+		verify.NoError(t, err)
+		verify.Equal(t, v1, u)
+	}
+
+	// Test invalid versions
+	_, err = uuidV6.ToV6()
+	verify.True(t, errors.Is(err, uuid.ErrInvalidVersion))
+	_, err = uuidV1.ToV1()
+	verify.True(t, errors.Is(err, uuid.ErrInvalidVersion))
+	_, err = uuid.New().ToV6()
+	verify.ErrorContains(t, err, "cannot convert version 4 to version 6")
+}
+
+// TestTime tests extracting the timestamp of time-based UUIDs.
+func TestTime(t *testing.T) {
+	// Test vectors of RFC 9562 Appendix A, all created at
+	// Tuesday, February 22, 2022 2:22:22.00 PM GMT-05:00.
+	expected := time.Date(2022, time.February, 22, 19, 22, 22, 0, time.UTC)
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"v1-rfc", "C232AB00-9414-11EC-B3C8-9F6BDECED846"},
+		{"v6-rfc", "1EC9414C-232A-6B00-B3C8-9F6BDECED846"},
+		{"v7-rfc", "017F22E2-79B0-7CC3-98C4-DC0C0C07398F"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u, err := uuid.Parse(test.source)
+			verify.NoError(t, err)
+			ts, err := u.Time()
+			verify.NoError(t, err)
+			verify.True(t, ts.Equal(expected), "time must match RFC test vector")
+		})
+	}
+
+	// Test generated UUIDs
+	before := time.Now()
+	uuidV1, err := uuid.NewV1()
+	verify.NoError(t, err)
+	uuidV6, err := uuid.NewV6()
+	verify.NoError(t, err)
+	uuidV7, err := uuid.NewV7()
+	verify.NoError(t, err)
+	after := time.Now()
+
+	ts, err := uuidV1.Time()
+	verify.NoError(t, err)
+	verify.Between(t, ts, before.Truncate(time.Microsecond), after)
+
+	ts, err = uuidV6.Time()
+	verify.NoError(t, err)
+	verify.Between(t, ts, before.Truncate(time.Microsecond), after)
+
+	ts, err = uuidV7.Time()
+	verify.NoError(t, err)
+	verify.Between(t, ts, before.Truncate(time.Millisecond), after)
+
+	// Test UUIDs without time
+	_, err = uuid.New().Time()
+	verify.ErrorContains(t, err, "UUID version 4 contains no time")
+}
+
+// TestFormatAndParseBytes tests formatting into and parsing from
+// byte slices.
+func TestFormatAndParseBytes(t *testing.T) {
+	u, err := uuid.Parse("017F22E2-79B0-7CC3-98C4-DC0C0C07398F")
+	verify.NoError(t, err)
+	verify.Equal(t, u.String(), "017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	verify.Equal(t, u.ShortString(), "017f22e279b07cc398c4dc0c0c07398f")
+
+	buf := []byte("id=")
+	buf = u.AppendString(buf)
+	verify.Equal(t, string(buf), "id=017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	buf = u.AppendShortString(buf[:3])
+	verify.Equal(t, string(buf), "id=017f22e279b07cc398c4dc0c0c07398f")
+
+	for _, source := range []string{
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"URN:UUID:017F22E2-79B0-7CC3-98C4-DC0C0C07398F",
+		"{017f22e2-79b0-7cc3-98c4-dc0c0c07398f}",
+		"017F22E279B07CC398C4DC0C0C07398F",
+	} {
+		parsed, err := uuid.ParseBytes([]byte(source))
+		verify.NoError(t, err)
+		verify.Equal(t, parsed, u)
+	}
+	_, err = uuid.ParseBytes([]byte("017f22e2-79b0-7cc3-98c4-dc0c0c07398g"))
+	verify.True(t, errors.Is(err, uuid.ErrInvalidFormat))
+}
+
+// TestZeroAllocations tests that appending and parsing do not allocate.
+func TestZeroAllocations(t *testing.T) {
+	u, err := uuid.NewV7()
+	verify.NoError(t, err)
+	source := u.String()
+	sourceBytes := []byte(source)
+	buf := make([]byte, 0, 64)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = u.AppendString(buf[:0])
+		buf = u.AppendShortString(buf[:0])
+		_, _ = uuid.Parse(source)
+		_, _ = uuid.ParseBytes(sourceBytes)
+	})
+	verify.Equal(t, allocs, 0.0)
+}
+
+// TestTimeBasedFields tests the accessors for clock sequence, node,
+// and counter of time-based UUIDs.
+func TestTimeBasedFields(t *testing.T) {
+	node := [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}
+
+	// Test vectors of RFC 9562 Appendix A
+	uuidV1, err := uuid.Parse("C232AB00-9414-11EC-B3C8-9F6BDECED846")
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV1.ClockSequence(), uint16(0x33c8))
+	verify.Equal(t, uuidV1.NodeID(), node)
+
+	uuidV6, err := uuid.Parse("1EC9414C-232A-6B00-B3C8-9F6BDECED846")
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV6.ClockSequence(), uint16(0x33c8))
+	verify.Equal(t, uuidV6.NodeID(), node)
+
+	uuidV7, err := uuid.Parse("017F22E2-79B0-7CC3-98C4-DC0C0C07398F")
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV7.Counter(), uint16(0x0cc3))
+
+	// Test v2 with the domain in the clock sequence low byte
+	uuidV2, err := uuid.NewV2(uuid.Org, 4711)
+	verify.NoError(t, err)
+	verify.True(t, uuidV2.ClockSequence() <= 0x3f, "v2 clock sequence has 6 bits")
+
+	// Test generated v6 and v7 UUIDs share node and count up
+	uuidV6A, err := uuid.NewV6()
+	verify.NoError(t, err)
+	uuidV6B, err := uuid.NewV6()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV6A.NodeID(), uuidV6B.NodeID())
+
+	// Fixed clock keeps both v7 UUIDs in the same millisecond
+	now := time.Date(2025, time.December, 24, 18, 0, 0, 0, time.UTC)
+	gen := uuid.NewGenerator(
+		uuid.WithClock(func() time.Time { return now }),
+		uuid.WithRandom(&sequenceReader{}),
+	)
+	uuidV7A, err := gen.NewV7()
+	verify.NoError(t, err)
+	uuidV7B, err := gen.NewV7()
+	verify.NoError(t, err)
+	verify.Equal(t, uuidV7B.Counter(), uuidV7A.Counter()+1)
+}
+
 // BenchmarkNewV1 benchmarks UUID v1 generation.
 func BenchmarkNewV1(b *testing.B) {
 	for b.Loop() {
@@ -371,10 +831,13 @@ func BenchmarkNewV1(b *testing.B) {
 	}
 }
 
-// BenchmarkNewV2 benchmarks UUID v2 generation.
+// BenchmarkNewV2 benchmarks UUID v2 generation. The id changes, as
+// only 64 UUIDs per domain and id can be generated within 7 minutes.
 func BenchmarkNewV2(b *testing.B) {
+	id := uint32(0)
 	for b.Loop() {
-		_, _ = uuid.NewV2(uuid.Person, 1000)
+		id++
+		_, _ = uuid.NewV2(uuid.Person, id)
 	}
 }
 
@@ -428,20 +891,59 @@ func BenchmarkParse(b *testing.B) {
 	u, _ := uuid.NewV7()
 	s := u.String()
 
+	b.ReportAllocs()
 	for b.Loop() {
 		_, _ = uuid.Parse(s)
 	}
 }
 
-// BenchmarkString benchmarks UUID string formatting.
+// BenchmarkString benchmarks UUID string formatting. The only
+// allocation is the returned string.
 func BenchmarkString(b *testing.B) {
 	u, _ := uuid.NewV7()
 
+	b.ReportAllocs()
 	for b.Loop() {
 		_ = u.String()
 	}
 }
 
+// BenchmarkAppendString benchmarks appending the UUID string to a
+// buffer without allocations.
+func BenchmarkAppendString(b *testing.B) {
+	u, _ := uuid.NewV7()
+	buf := make([]byte, 0, 64)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = u.AppendString(buf[:0])
+	}
+}
+
+// BenchmarkAppendShortString benchmarks appending the short UUID string
+// to a buffer without allocations.
+func BenchmarkAppendShortString(b *testing.B) {
+	u, _ := uuid.NewV7()
+	buf := make([]byte, 0, 64)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		buf = u.AppendShortString(buf[:0])
+	}
+}
+
+// BenchmarkParseBytes benchmarks UUID parsing of byte slices without
+// allocations.
+func BenchmarkParseBytes(b *testing.B) {
+	u, _ := uuid.NewV7()
+	s := []byte(u.String())
+
+	b.ReportAllocs()
+	for b.Loop() {
+		_, _ = uuid.ParseBytes(s)
+	}
+}
+
 // FuzzV2Domain fuzzes UUID v2 with various domain values.
 func FuzzV2Domain(f *testing.F) {
 	// Add seed corpus